@@ -0,0 +1,77 @@
+package cloudhealth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+// defaultOperationTimeout bounds each CRUD operation unless the resource's
+// timeouts block says otherwise.
+const defaultOperationTimeout = 10 * time.Minute
+
+// providerMeta is what providerConfigure hands to resources and data sources.
+type providerMeta struct {
+	client *cloudhealth.Client
+
+	// stopContext is cancelled when Terraform interrupts the run (e.g. Ctrl-C).
+	stopContext context.Context
+}
+
+// operationContext returns the context a CRUD function calls CloudHealth with.
+// It is cancelled when Terraform is interrupted, and expires after the timeout
+// configured for the operation, e.g. schema.TimeoutCreate.
+func operationContext(d *schema.ResourceData, m interface{}, timeoutKey string) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(m.(*providerMeta).stopContext, d.Timeout(timeoutKey))
+}
+
+// resourceTimeouts allows every operation of a resource to be bounded with a
+// timeouts block.
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultOperationTimeout),
+		Read:   schema.DefaultTimeout(defaultOperationTimeout),
+		Update: schema.DefaultTimeout(defaultOperationTimeout),
+		Delete: schema.DefaultTimeout(defaultOperationTimeout),
+	}
+}
+
+// clientAPIIDSchema is the per-resource override of the provider's
+// client_api_id, for managing the resource inside a partner customer tenant.
+func clientAPIIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		ForceNew: true,
+	}
+}
+
+// clientFor returns the client scoped to the resource's client_api_id, or the
+// provider's client when the resource doesn't override it.
+func clientFor(d *schema.ResourceData, m interface{}) *cloudhealth.Client {
+	client := m.(*providerMeta).client
+	if id, ok := d.GetOk("client_api_id"); ok {
+		return client.WithClientAPIID(id.(int))
+	}
+	return client
+}
+
+// importStateWithClientAPIID accepts "<client_api_id>/<id>" as well as a bare
+// ID, so resources inside a partner customer tenant can be imported.
+func importStateWithClientAPIID(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) == 2 {
+		clientAPIID, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid client_api_id in import ID %q: %v", d.Id(), err)
+		}
+		d.Set("client_api_id", clientAPIID)
+		d.SetId(parts[1])
+	}
+	return []*schema.ResourceData{d}, nil
+}
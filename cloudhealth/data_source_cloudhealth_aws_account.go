@@ -0,0 +1,90 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func dataSourceCloudHealthAwsAccount() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudHealthAwsAccountRead,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCloudHealthAwsAccountRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutRead)
+	defer cancel()
+
+	var account *cloudhealth.AwsAccount
+	if id, ok := d.GetOk("account_id"); ok {
+		var err error
+		account, err = client.GetAwsAccountWithContext(ctx, id.(int))
+		if err != nil {
+			return fmt.Errorf("Error when reading AWS Account %d: %v", id, err)
+		}
+	} else {
+		name := d.Get("name").(string)
+		ownerID := d.Get("owner_id").(string)
+		if name == "" && ownerID == "" {
+			return fmt.Errorf("One of account_id, name or owner_id must be set")
+		}
+
+		accounts, err := client.GetAllAwsAccountsWithContext(ctx, awsAccountsPerPage)
+		if err != nil {
+			return err
+		}
+
+		nameRegex := ""
+		if name != "" {
+			nameRegex = "^" + regexp.QuoteMeta(name) + "$"
+		}
+		accounts, err = filterAwsAccounts(accounts, nameRegex, ownerID, "")
+		if err != nil {
+			return err
+		}
+
+		switch len(accounts) {
+		case 0:
+			return fmt.Errorf("No AWS Account found matching name %q and owner_id %q", name, ownerID)
+		case 1:
+			account = &accounts[0]
+		default:
+			return fmt.Errorf("%d AWS Accounts found matching name %q and owner_id %q; please narrow the search", len(accounts), name, ownerID)
+		}
+	}
+
+	d.SetId(strconv.Itoa(account.ID))
+	d.Set("account_id", account.ID)
+	d.Set("name", account.Name)
+	d.Set("owner_id", account.OwnerID)
+	d.Set("protocol", account.Authentication.Protocol)
+
+	return nil
+}
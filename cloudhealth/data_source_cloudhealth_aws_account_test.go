@@ -0,0 +1,42 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudHealthAwsAccountDataSource_byName(t *testing.T) {
+	accountName := fmt.Sprintf("account-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthAwsAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudHealthAwsAccountDataSourceConfig(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.cloudhealth_aws_account.selected", "id", "cloudhealth_aws_account.account", "id"),
+					resource.TestCheckResourceAttr("data.cloudhealth_aws_account.selected", "protocol", "access_key"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloudHealthAwsAccountDataSourceConfig(r string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_aws_account" "account" {
+  name = "%s"
+  authentication {
+    protocol = "access_key"
+  }
+}
+
+data "cloudhealth_aws_account" "selected" {
+  name = "${cloudhealth_aws_account.account.name}"
+}
+`, r)
+}
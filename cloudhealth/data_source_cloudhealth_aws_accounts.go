@@ -0,0 +1,142 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+// awsAccountsPerPage is the page size used when listing AWS Accounts.
+const awsAccountsPerPage = 100
+
+func dataSourceCloudHealthAwsAccounts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudHealthAwsAccountsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRegexp,
+			},
+			"owner_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"accounts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocol": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudHealthAwsAccountsRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutRead)
+	defer cancel()
+
+	accounts, err := client.GetAllAwsAccountsWithContext(ctx, awsAccountsPerPage)
+	if err != nil {
+		return err
+	}
+
+	accounts, err = filterAwsAccounts(accounts, d.Get("name_regex").(string), d.Get("owner_id").(string), d.Get("protocol").(string))
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(accounts))
+	names := make([]string, 0, len(accounts))
+	accountList := make([]map[string]interface{}, 0, len(accounts))
+	for _, account := range accounts {
+		id := strconv.Itoa(account.ID)
+		ids = append(ids, id)
+		names = append(names, account.Name)
+		accountList = append(accountList, map[string]interface{}{
+			"id":       id,
+			"name":     account.Name,
+			"owner_id": account.OwnerID,
+			"protocol": account.Authentication.Protocol,
+		})
+	}
+
+	d.SetId(hashcode.Strings(ids))
+	d.Set("ids", ids)
+	d.Set("names", names)
+	d.Set("accounts", accountList)
+
+	return nil
+}
+
+// filterAwsAccounts returns the accounts matching every non-empty filter.
+func filterAwsAccounts(accounts []cloudhealth.AwsAccount, nameRegex, ownerID, protocol string) ([]cloudhealth.AwsAccount, error) {
+	var re *regexp.Regexp
+	if nameRegex != "" {
+		var err error
+		re, err = regexp.Compile(nameRegex)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid name_regex %q: %v", nameRegex, err)
+		}
+	}
+
+	result := make([]cloudhealth.AwsAccount, 0, len(accounts))
+	for _, account := range accounts {
+		if re != nil && !re.MatchString(account.Name) {
+			continue
+		}
+		if ownerID != "" && account.OwnerID != ownerID {
+			continue
+		}
+		if protocol != "" && account.Authentication.Protocol != protocol {
+			continue
+		}
+		result = append(result, account)
+	}
+	return result, nil
+}
+
+func validateRegexp(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := regexp.Compile(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/nextgenhealthcare/terraform-provider-cloudhealth/internal/fakeapi"
+)
+
+func TestAccCloudHealthAwsAccounts_basic(t *testing.T) {
+	accountName := fmt.Sprintf("account-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthAwsAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudHealthAwsAccountsConfig(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudhealth_aws_accounts.selected", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.cloudhealth_aws_accounts.selected", "ids.0", "cloudhealth_aws_account.account", "id"),
+					resource.TestCheckResourceAttr("data.cloudhealth_aws_accounts.selected", "accounts.0.protocol", "access_key"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthAwsAccounts_basic(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	accountName := fmt.Sprintf("account-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthAwsAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testAccCloudHealthAwsAccountsConfig(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cloudhealth_aws_accounts.selected", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.cloudhealth_aws_accounts.selected", "ids.0", "cloudhealth_aws_account.account", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloudHealthAwsAccountsConfig(r string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_aws_account" "account" {
+  name = "%s"
+  authentication {
+    protocol = "access_key"
+  }
+}
+
+data "cloudhealth_aws_accounts" "selected" {
+  name_regex = "^${cloudhealth_aws_account.account.name}$"
+  protocol   = "access_key"
+}
+`, r)
+}
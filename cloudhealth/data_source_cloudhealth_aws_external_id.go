@@ -2,7 +2,6 @@ package cloudhealth
 
 import (
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
 )
 
 type ExternalID struct {
@@ -14,6 +13,10 @@ func dataSourceCloudHealthAwsExternalId() *schema.Resource {
 		Read: dataSourceAwsOrganizationsOrganizationRead,
 
 		Schema: map[string]*schema.Schema{
+			"client_api_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
 			"external_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -23,9 +26,11 @@ func dataSourceCloudHealthAwsExternalId() *schema.Resource {
 }
 
 func dataSourceAwsOrganizationsOrganizationRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*cloudhealth.Client)
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutRead)
+	defer cancel()
 
-	id, err := client.GetAwsExternalID()
+	id, err := client.GetAwsExternalIDWithContext(ctx)
 	if err != nil {
 		return err
 	}
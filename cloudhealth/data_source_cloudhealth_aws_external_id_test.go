@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/nextgenhealthcare/terraform-provider-cloudhealth/internal/fakeapi"
 )
 
 func TestAccCloudHealthAwsExternalID_basic(t *testing.T) {
@@ -23,6 +24,24 @@ func TestAccCloudHealthAwsExternalID_basic(t *testing.T) {
 	})
 }
 
+func TestUnitCloudHealthAwsExternalID_basic(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testAccCloudHealthAwsExternalIDConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthAwsExternalIDExists("data.cloudhealth_aws_external_id.selected"),
+					resource.TestCheckResourceAttr("data.cloudhealth_aws_external_id.selected", "external_id", fakeapi.ExternalID),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckCloudHealthAwsExternalIDExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -0,0 +1,128 @@
+package cloudhealth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func dataSourceCloudHealthPerspective() *schema.Resource {
+	// Mirror the resource so buildPerspective can populate both
+	s := computedSchema(resourceCloudHealthPerspective().Schema)
+	delete(s, "on_destroy")
+	delete(s, "adopt_archived")
+	delete(s, "client_api_id")
+	s["perspective_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+	s["name"].Optional = true
+	// Map of group name to ref_id
+	s["group_ref_ids"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+	// Map of group name to type (filter or categorize)
+	s["group_types"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceCloudHealthPerspectiveRead,
+		Schema: s,
+	}
+}
+
+func dataSourceCloudHealthPerspectiveRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutRead)
+	defer cancel()
+
+	id := d.Get("perspective_id").(string)
+	if id == "" {
+		name := d.Get("name").(string)
+		if name == "" {
+			return fmt.Errorf("One of perspective_id or name must be set")
+		}
+
+		var err error
+		id, err = findActivePerspectiveID(ctx, client, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	perspective, err := client.GetPerspectiveWithContext(ctx, id)
+	if err != nil {
+		return fmt.Errorf("Error when reading perspective %s: %v", id, err)
+	}
+
+	err = buildPerspective(perspective, d)
+	if err != nil {
+		return err
+	}
+
+	refIDs := make(map[string]interface{})
+	types := make(map[string]interface{})
+	for _, group := range buildGroups(perspective) {
+		name := group["name"].(string)
+		refIDs[name] = group["ref_id"]
+		types[name] = group["type"]
+	}
+
+	d.SetId(id)
+	d.Set("perspective_id", id)
+	d.Set("group_ref_ids", refIDs)
+	d.Set("group_types", types)
+
+	return nil
+}
+
+// findActivePerspectiveID looks up the ID of the single active perspective with the given name.
+func findActivePerspectiveID(ctx context.Context, client *cloudhealth.Client, name string) (string, error) {
+	perspectiveMap, err := client.GetAllPerspectivesWithContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+	for _, id := range sortedPerspectiveIDs(*perspectiveMap) {
+		status := (*perspectiveMap)[id]
+		if status.Active && status.Name == name {
+			ids = append(ids, id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("No active perspective found with name %q", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d active perspectives found with name %q: %v", len(ids), name, ids)
+	}
+}
+
+// computedSchema returns a copy of a resource schema with every attribute
+// marked as Computed, for data sources that share the resource's shape.
+func computedSchema(src map[string]*schema.Schema) map[string]*schema.Schema {
+	dst := make(map[string]*schema.Schema, len(src))
+	for k, v := range src {
+		s := &schema.Schema{
+			Type:     v.Type,
+			Computed: true,
+			Elem:     v.Elem,
+		}
+		if r, ok := v.Elem.(*schema.Resource); ok {
+			s.Elem = &schema.Resource{Schema: computedSchema(r.Schema)}
+		}
+		dst[k] = s
+	}
+	return dst
+}
@@ -0,0 +1,36 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudHealthPerspectiveDataSource_byName(t *testing.T) {
+	perspectiveName := fmt.Sprintf("perspective-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudHealthPerspectiveDataSourceConfig(perspectiveName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.cloudhealth_perspective.selected", "id", "cloudhealth_perspective.acc_test_perspective", "id"),
+					resource.TestCheckResourceAttrPair("data.cloudhealth_perspective.selected", "group_ref_ids.OwnerAccTest", "cloudhealth_perspective.acc_test_perspective", "group.0.ref_id"),
+					resource.TestCheckResourceAttr("data.cloudhealth_perspective.selected", "group_types.OwnerAccTest", "categorize"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloudHealthPerspectiveDataSourceConfig(r string) string {
+	return testAccCloudHealthPerspectiveWithDefaults(r) + `
+data "cloudhealth_perspective" "selected" {
+  name = "${cloudhealth_perspective.acc_test_perspective.name}"
+}
+`
+}
@@ -0,0 +1,109 @@
+package cloudhealth
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func dataSourceCloudHealthPerspectives() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCloudHealthPerspectivesRead,
+
+		Schema: map[string]*schema.Schema{
+			"active_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Map of perspective name to ID. When an archived and an active
+			// perspective share a name, the active one wins.
+			"ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"perspectives": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"active": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCloudHealthPerspectivesRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutRead)
+	defer cancel()
+
+	perspectiveMap, err := client.GetAllPerspectivesWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	activeOnly := d.Get("active_only").(bool)
+	ids := sortedPerspectiveIDs(*perspectiveMap)
+
+	idByName := make(map[string]interface{})
+	activeByName := make(map[string]bool)
+	perspectives := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		status := (*perspectiveMap)[id]
+		if activeOnly && !status.Active {
+			continue
+		}
+
+		if _, ok := idByName[status.Name]; !ok || (status.Active && !activeByName[status.Name]) {
+			idByName[status.Name] = id
+			activeByName[status.Name] = status.Active
+		}
+
+		perspectives = append(perspectives, map[string]interface{}{
+			"id":     id,
+			"name":   status.Name,
+			"active": status.Active,
+		})
+	}
+
+	d.SetId(hashcode.Strings(append(ids, strconv.FormatBool(activeOnly))))
+	d.Set("ids", idByName)
+	d.Set("perspectives", perspectives)
+
+	return nil
+}
+
+// sortedPerspectiveIDs returns the IDs of the perspective map in numeric order.
+func sortedPerspectiveIDs(perspectiveMap cloudhealth.PerspectiveMap) []string {
+	ids := make([]string, 0, len(perspectiveMap))
+	for id := range perspectiveMap {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, errA := strconv.Atoi(ids[i])
+		b, errB := strconv.Atoi(ids[j])
+		if errA != nil || errB != nil {
+			return ids[i] < ids[j]
+		}
+		return a < b
+	})
+	return ids
+}
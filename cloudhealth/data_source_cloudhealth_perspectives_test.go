@@ -0,0 +1,35 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccCloudHealthPerspectives_basic(t *testing.T) {
+	perspectiveName := fmt.Sprintf("perspective-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudHealthPerspectivesConfig(perspectiveName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.cloudhealth_perspectives.active", fmt.Sprintf("ids.%s", perspectiveName), "cloudhealth_perspective.acc_test_perspective", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCloudHealthPerspectivesConfig(r string) string {
+	return testAccCloudHealthPerspectiveWithDefaults(r) + `
+data "cloudhealth_perspectives" "active" {
+  active_only = true
+  depends_on  = ["cloudhealth_perspective.acc_test_perspective"]
+}
+`
+}
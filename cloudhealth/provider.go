@@ -1,13 +1,19 @@
 package cloudhealth
 
 import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/logging"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/httpclient"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
 )
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_key": {
 				Type:        schema.TypeString,
@@ -27,22 +33,141 @@ func Provider() terraform.ResourceProvider {
 				Description: "API URL",
 				DefaultFunc: schema.EnvDefaultFunc("CLOUDHEALTH_API_URL", "https://chapi.cloudhealthtech.com/v1/"),
 			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum number of times a throttled or transiently failed API call is retried. Calls that create objects are not retried.",
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDHEALTH_MAX_RETRIES", 3),
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum time to wait between retries (in seconds).",
+				DefaultFunc:  schema.EnvDefaultFunc("CLOUDHEALTH_RETRY_MAX_WAIT", 30),
+				ValidateFunc: validateIntAtLeast(1),
+			},
+			"requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Description: "Maximum average number of API calls per second, shared by all resources. 0 means unlimited.",
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDHEALTH_REQUESTS_PER_SECOND", 0),
+			},
+			"burst": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum number of API calls sent at once when requests_per_second is set.",
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDHEALTH_BURST", 10),
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Proxy to reach the API through, with credentials if it requires them. Defaults to the HTTPS_PROXY environment variable.",
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDHEALTH_PROXY_URL", ""),
+			},
+			"ca_bundle_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM file of certificate authorities to trust in addition to the system ones.",
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDHEALTH_CA_BUNDLE_FILE", ""),
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skip verification of the API's TLS certificate.",
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDHEALTH_INSECURE_SKIP_VERIFY", false),
+			},
+			"client_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM client certificate for mutual TLS.",
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDHEALTH_CLIENT_CERT_FILE", ""),
+			},
+			"client_key_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM key of the client certificate for mutual TLS.",
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDHEALTH_CLIENT_KEY_FILE", ""),
+			},
+			"client_api_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "CloudHealth ID of the partner customer to act on behalf of.",
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDHEALTH_CLIENT_API_ID", nil),
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
+			"cloudhealth_aws_account":     dataSourceCloudHealthAwsAccount(),
+			"cloudhealth_aws_accounts":    dataSourceCloudHealthAwsAccounts(),
 			"cloudhealth_aws_external_id": dataSourceCloudHealthAwsExternalId(),
+			"cloudhealth_perspective":     dataSourceCloudHealthPerspective(),
+			"cloudhealth_perspectives":    dataSourceCloudHealthPerspectives(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"cloudhealth_aws_account": resourceCloudHealthAwsAccount(),
-			"cloudhealth_perspective": resourceCloudHealthPerspective(),
+			"cloudhealth_aws_account":        resourceCloudHealthAwsAccount(),
+			"cloudhealth_azure_subscription": resourceCloudHealthAzureSubscription(),
+			"cloudhealth_customer":           resourceCloudHealthCustomer(),
+			"cloudhealth_gcp_project":        resourceCloudHealthGcpProject(),
+			"cloudhealth_perspective":        resourceCloudHealthPerspective(),
+			"cloudhealth_perspective_json":   resourceCloudHealthPerspectiveJSON(),
 		},
-		ConfigureFunc: providerConfigure,
 	}
+	p.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		client, err := providerConfigure(d)
+		if err != nil {
+			return nil, err
+		}
+		return &providerMeta{
+			client:      client,
+			stopContext: p.StopContext(),
+		}, nil
+	}
+	return p
+}
+
+func providerConfigure(d *schema.ResourceData) (*cloudhealth.Client, error) {
+	if d.Get("insecure_skip_verify").(bool) {
+		log.Printf("[WARN] Verification of the CloudHealth API's TLS certificate is disabled")
+	}
+	transport, err := cloudhealth.NewTransport(cloudhealth.TransportConfig{
+		ProxyURL:           d.Get("proxy_url").(string),
+		CABundleFile:       d.Get("ca_bundle_file").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+		ClientCertFile:     d.Get("client_cert_file").(string),
+		ClientKeyFile:      d.Get("client_key_file").(string),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not set up the connection to CloudHealth: %v", err)
+	}
+
+	opts := []cloudhealth.Option{
+		cloudhealth.WithBaseTransport(transport),
+		cloudhealth.WithTimeout(d.Get("timeout").(int)),
+		cloudhealth.WithUserAgent(fmt.Sprintf("terraform-provider-cloudhealth %s", httpclient.UserAgentString())),
+		cloudhealth.WithRateLimit(d.Get("requests_per_second").(float64), d.Get("burst").(int)),
+	}
+	if logging.IsDebugOrHigher() {
+		opts = append(opts, cloudhealth.WithLogger(debugLogger{}))
+	}
+
+	client, err := cloudhealth.NewClient(d.Get("api_key").(string), d.Get("url").(string), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client.MaxRetries = d.Get("max_retries").(int)
+	client.RetryWaitMax = time.Second * time.Duration(d.Get("retry_max_wait").(int))
+	if client.RetryWaitMin > client.RetryWaitMax {
+		client.RetryWaitMin = client.RetryWaitMax
+	}
+	client.ClientAPIID = d.Get("client_api_id").(int)
+
+	return client, nil
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	return cloudhealth.NewClient(
-		d.Get("api_key").(string),
-		d.Get("url").(string),
-		d.Get("timeout").(int),
-	)
+// debugLogger sends the SDK's request logs to Terraform's log at DEBUG level.
+type debugLogger struct{}
+
+func (debugLogger) Printf(format string, v ...interface{}) {
+	log.Printf("[DEBUG] "+format, v...)
 }
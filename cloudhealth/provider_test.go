@@ -1,11 +1,20 @@
 package cloudhealth
 
 import (
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+	"github.com/nextgenhealthcare/terraform-provider-cloudhealth/internal/fakeapi"
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
@@ -28,8 +37,159 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestUnitProvider_caBundle(t *testing.T) {
+	api := fakeapi.NewTLSServer()
+	defer api.Close()
+
+	dir, err := ioutil.TempDir("", "cloudhealth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	caBundleFile := filepath.Join(dir, "ca.pem")
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: api.Certificate().Raw})
+	if err := ioutil.WriteFile(caBundleFile, caBundle, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "cloudhealth" {
+  api_key        = "%s"
+  url            = "%s"
+  ca_bundle_file = "%s"
+}
+
+data "cloudhealth_aws_external_id" "selected" {}
+`, fakeapi.APIKey, api.EndpointURL(), caBundleFile),
+				Check: resource.TestCheckResourceAttr("data.cloudhealth_aws_external_id.selected", "external_id", fakeapi.ExternalID),
+			},
+		},
+	})
+}
+
+func TestUnitProvider_insecureSkipVerify(t *testing.T) {
+	api := fakeapi.NewTLSServer()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "cloudhealth" {
+  api_key     = "%s"
+  url         = "%s"
+  max_retries = 0
+}
+
+data "cloudhealth_aws_external_id" "selected" {}
+`, fakeapi.APIKey, api.EndpointURL()),
+				ExpectError: regexp.MustCompile(`certificate`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "cloudhealth" {
+  api_key              = "%s"
+  url                  = "%s"
+  insecure_skip_verify = true
+}
+
+data "cloudhealth_aws_external_id" "selected" {}
+`, fakeapi.APIKey, api.EndpointURL()),
+				Check: resource.TestCheckResourceAttr("data.cloudhealth_aws_external_id.selected", "external_id", fakeapi.ExternalID),
+			},
+		},
+	})
+}
+
+func TestUnitProvider_clientCertWithoutKey(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "cloudhealth" {
+  api_key          = "%s"
+  url              = "%s"
+  client_cert_file = "client.pem"
+}
+
+data "cloudhealth_aws_external_id" "selected" {}
+`, fakeapi.APIKey, api.EndpointURL()),
+				ExpectError: regexp.MustCompile(`Could not set up the connection to CloudHealth: a client certificate and key must be given together`),
+			},
+		},
+	})
+}
+
+func TestUnitProvider_retryMaxWait(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "cloudhealth" {
+  api_key        = "%s"
+  url            = "%s"
+  retry_max_wait = 0
+}
+
+data "cloudhealth_aws_external_id" "selected" {}
+`, fakeapi.APIKey, api.EndpointURL()),
+				ExpectError: regexp.MustCompile(`retry_max_wait.*at least 1`),
+			},
+		},
+	})
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("CLOUDHEALTH_API_KEY"); v == "" {
 		t.Fatal("CLOUDHEALTH_API_KEY must be set for acceptance tests")
 	}
 }
+
+// testAccClientFor returns the provider's client, scoped to the resource's
+// client_api_id when it has one.
+func testAccClientFor(r *terraform.ResourceState) *cloudhealth.Client {
+	client := testAccProvider.Meta().(*providerMeta).client
+	if id, err := strconv.Atoi(r.Primary.Attributes["client_api_id"]); err == nil && id != 0 {
+		return client.WithClientAPIID(id)
+	}
+	return client
+}
+
+// testUnitCheckTenant checks the partner customer tenant the fake API holds
+// the resource in, i.e. the client_api_id it was created with.
+func testUnitCheckTenant(api *fakeapi.Server, n string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		if tenant := api.Tenant(r.Primary.ID); tenant != expected {
+			return fmt.Errorf("Expected %s in tenant %q, got %q", n, expected, tenant)
+		}
+		return nil
+	}
+}
+
+// testUnitProviderConfig points the provider at a fake CloudHealth API, for
+// tests run with resource.UnitTest.
+func testUnitProviderConfig(api *fakeapi.Server) string {
+	return fmt.Sprintf(`
+provider "cloudhealth" {
+  api_key = "%s"
+  url     = "%s"
+}
+`, fakeapi.APIKey, api.EndpointURL())
+}
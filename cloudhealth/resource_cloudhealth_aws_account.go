@@ -1,9 +1,12 @@
 package cloudhealth
 
 import (
+	"errors"
+	"sort"
+	"strconv"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
-	"strconv"
 )
 
 func resourceCloudHealthAwsAccount() *schema.Resource {
@@ -13,10 +16,12 @@ func resourceCloudHealthAwsAccount() *schema.Resource {
 		Update: resourceCloudHealthAwsAccountUpdate,
 		Delete: resourceCloudHealthAwsAccountDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateWithClientAPIID,
 		},
+		Timeouts: resourceTimeouts(),
 
 		Schema: map[string]*schema.Schema{
+			"client_api_id": clientAPIIDSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -55,21 +60,85 @@ func resourceCloudHealthAwsAccount() *schema.Resource {
 					},
 				},
 			},
+			"cloudtrail": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem:     resourceCloudHealthAwsAccountBucket(),
+			},
+			"aws_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem:     resourceCloudHealthAwsAccountBucket(),
+			},
+			"cloudwatch": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+			"enabled_regions": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"hide_public_fields": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"cluster_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceCloudHealthAwsAccountBucket is the schema shared by the S3 backed
+// collection settings (CloudTrail and AWS Config).
+func resourceCloudHealthAwsAccountBucket() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 		},
 	}
 }
 
 func resourceCloudHealthAwsAccountCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*cloudhealth.Client)
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutCreate)
+	defer cancel()
 
-	account, err := client.CreateAwsAccount(cloudhealth.AwsAccount{
-		Name: d.Get("name").(string),
-		Authentication: cloudhealth.AwsAccountAuthentication{
-			Protocol:             d.Get("authentication.0.protocol").(string),
-			AssumeRoleArn:        d.Get("authentication.0.assume_role_arn").(string),
-			AssumeRoleExternalID: d.Get("authentication.0.assume_role_external_id").(string),
-		},
-	})
+	account, err := client.CreateAwsAccountWithContext(ctx, convertAwsAccount(d))
 	if err != nil {
 		return err
 	}
@@ -80,11 +149,13 @@ func resourceCloudHealthAwsAccountCreate(d *schema.ResourceData, m interface{})
 }
 
 func resourceCloudHealthAwsAccountRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*cloudhealth.Client)
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutRead)
+	defer cancel()
 
 	id, _ := strconv.Atoi(d.Id())
-	account, err := client.GetAwsAccount(id)
-	if err == cloudhealth.ErrAwsAccountNotFound {
+	account, err := client.GetAwsAccountWithContext(ctx, id)
+	if errors.Is(err, cloudhealth.ErrAwsAccountNotFound) {
 		d.SetId("")
 		return nil
 	}
@@ -101,24 +172,60 @@ func resourceCloudHealthAwsAccountRead(d *schema.ResourceData, m interface{}) er
 	authList = append(authList, auth)
 	d.Set("authentication", authList)
 
+	cloudTrailList := make([]map[string]interface{}, 0, 1)
+	if account.CloudTrail != nil {
+		cloudTrailList = append(cloudTrailList, map[string]interface{}{
+			"enabled": account.CloudTrail.Enabled,
+			"bucket":  account.CloudTrail.Bucket,
+			"prefix":  account.CloudTrail.Prefix,
+		})
+	}
+	d.Set("cloudtrail", cloudTrailList)
+
+	awsConfigList := make([]map[string]interface{}, 0, 1)
+	if account.AwsConfig != nil {
+		awsConfigList = append(awsConfigList, map[string]interface{}{
+			"enabled": account.AwsConfig.Enabled,
+			"bucket":  account.AwsConfig.Bucket,
+			"prefix":  account.AwsConfig.Prefix,
+		})
+	}
+	d.Set("aws_config", awsConfigList)
+
+	cloudWatchList := make([]map[string]interface{}, 0, 1)
+	if account.CloudWatch != nil {
+		cloudWatchList = append(cloudWatchList, map[string]interface{}{
+			"enabled": account.CloudWatch.Enabled,
+		})
+	}
+	d.Set("cloudwatch", cloudWatchList)
+
+	d.Set("enabled_regions", account.EnabledRegions)
+
+	tags := make(map[string]interface{})
+	for _, tag := range account.Tags {
+		tags[tag.Key] = tag.Value
+	}
+	d.Set("tags", tags)
+
+	if account.HidePublicFields != nil {
+		d.Set("hide_public_fields", *account.HidePublicFields)
+	}
+	d.Set("cluster_name", account.ClusterName)
+
 	return nil
 }
 
 func resourceCloudHealthAwsAccountUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*cloudhealth.Client)
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutUpdate)
+	defer cancel()
 
 	id, _ := strconv.Atoi(d.Id())
-	account := cloudhealth.AwsAccount{
-		ID:   id,
-		Name: d.Get("name").(string),
-		Authentication: cloudhealth.AwsAccountAuthentication{
-			Protocol:             d.Get("authentication.0.protocol").(string),
-			AssumeRoleArn:        d.Get("authentication.0.assume_role_arn").(string),
-			AssumeRoleExternalID: d.Get("authentication.0.assume_role_external_id").(string),
-		},
-	}
+	account := convertAwsAccount(d)
+	account.ID = id
 
-	updatedAccount, err := client.UpdateAwsAccount(account)
+	updatedAccount, err := client.UpdateAwsAccountWithContext(ctx, account)
 	if err != nil {
 		return err
 	}
@@ -129,10 +236,12 @@ func resourceCloudHealthAwsAccountUpdate(d *schema.ResourceData, m interface{})
 }
 
 func resourceCloudHealthAwsAccountDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*cloudhealth.Client)
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutDelete)
+	defer cancel()
 
 	id, _ := strconv.Atoi(d.Id())
-	err := client.DeleteAwsAccount(id)
+	err := client.DeleteAwsAccountWithContext(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -141,3 +250,68 @@ func resourceCloudHealthAwsAccountDelete(d *schema.ResourceData, m interface{})
 
 	return nil
 }
+
+func convertAwsAccount(d *schema.ResourceData) cloudhealth.AwsAccount {
+	account := cloudhealth.AwsAccount{
+		Name: d.Get("name").(string),
+		Authentication: cloudhealth.AwsAccountAuthentication{
+			Protocol:             d.Get("authentication.0.protocol").(string),
+			AssumeRoleArn:        d.Get("authentication.0.assume_role_arn").(string),
+			AssumeRoleExternalID: d.Get("authentication.0.assume_role_external_id").(string),
+		},
+		ClusterName: d.Get("cluster_name").(string),
+	}
+
+	if _, ok := d.GetOk("cloudtrail"); ok {
+		account.CloudTrail = &cloudhealth.AwsAccountCloudTrail{
+			Enabled: d.Get("cloudtrail.0.enabled").(bool),
+			Bucket:  d.Get("cloudtrail.0.bucket").(string),
+			Prefix:  d.Get("cloudtrail.0.prefix").(string),
+		}
+	}
+	if _, ok := d.GetOk("aws_config"); ok {
+		account.AwsConfig = &cloudhealth.AwsAccountAwsConfig{
+			Enabled: d.Get("aws_config.0.enabled").(bool),
+			Bucket:  d.Get("aws_config.0.bucket").(string),
+			Prefix:  d.Get("aws_config.0.prefix").(string),
+		}
+	}
+	if _, ok := d.GetOk("cloudwatch"); ok {
+		account.CloudWatch = &cloudhealth.AwsAccountCloudWatch{
+			Enabled: d.Get("cloudwatch.0.enabled").(bool),
+		}
+	}
+
+	// Leave out regions and tags that aren't set, so CloudHealth keeps its
+	// own, unless they have been removed from the configuration: an empty,
+	// non-nil list is sent as [] and clears them
+	regions := convertStringArray(d.Get("enabled_regions").(*schema.Set).List())
+	if len(regions) > 0 || d.HasChange("enabled_regions") {
+		account.EnabledRegions = regions
+	}
+
+	tags := d.Get("tags").(map[string]interface{})
+	if len(tags) > 0 || d.HasChange("tags") {
+		account.Tags = make([]cloudhealth.AwsAccountTag, 0, len(tags))
+	}
+	tagKeys := make([]string, 0, len(tags))
+	for k := range tags {
+		tagKeys = append(tagKeys, k)
+	}
+	sort.Strings(tagKeys)
+	for _, k := range tagKeys {
+		account.Tags = append(account.Tags, cloudhealth.AwsAccountTag{
+			Key:   k,
+			Value: tags[k].(string),
+		})
+	}
+
+	// Only send hide_public_fields when it has been set, so CloudHealth keeps
+	// its own default otherwise
+	if v, ok := d.GetOkExists("hide_public_fields"); ok {
+		hidePublicFields := v.(bool)
+		account.HidePublicFields = &hidePublicFields
+	}
+
+	return account
+}
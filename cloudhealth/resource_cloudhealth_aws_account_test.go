@@ -1,7 +1,10 @@
 package cloudhealth
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -9,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+	"github.com/nextgenhealthcare/terraform-provider-cloudhealth/internal/fakeapi"
 )
 
 func TestAccCloudHealthAwsAccount_basic(t *testing.T) {
@@ -28,12 +32,200 @@ func TestAccCloudHealthAwsAccount_basic(t *testing.T) {
 	})
 }
 
+func TestAccCloudHealthAwsAccount_collection(t *testing.T) {
+	accountName := fmt.Sprintf("account-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthAwsAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudHealthAwsAccountWithCollection(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthAwsAccountExists("cloudhealth_aws_account.account"),
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "cloudtrail.0.bucket", accountName+"-cloudtrail"),
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "cloudwatch.0.enabled", "true"),
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "tags.environment", "test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudHealthAwsAccount_clientAPIID(t *testing.T) {
+	accountName := fmt.Sprintf("account-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthAwsAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudHealthAwsAccountWithClientAPIID(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthAwsAccountExists("cloudhealth_aws_account.account"),
+					resource.TestCheckResourceAttrPair("cloudhealth_aws_account.account", "client_api_id", "cloudhealth_customer.customer", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthAwsAccount_basic(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	accountName := fmt.Sprintf("account-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthAwsAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testAccCloudHealthAwsAccountWithDefaults(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthAwsAccountExists("cloudhealth_aws_account.account"),
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "name", accountName),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(api) + testAccCloudHealthAwsAccountWithCollection(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthAwsAccountExists("cloudhealth_aws_account.account"),
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "cloudtrail.0.bucket", accountName+"-cloudtrail"),
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "cloudwatch.0.enabled", "true"),
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "tags.environment", "test"),
+				),
+			},
+			{
+				Config:            testUnitProviderConfig(api) + testAccCloudHealthAwsAccountWithCollection(accountName),
+				ResourceName:      "cloudhealth_aws_account.account",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthAwsAccount_removeTags(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	accountName := fmt.Sprintf("account-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthAwsAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testAccCloudHealthAwsAccountWithCollection(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "tags.environment", "test"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(api) + testAccCloudHealthAwsAccountWithDefaults(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "tags.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthAwsAccount_createWithoutRegions(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	accountName := fmt.Sprintf("account-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthAwsAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testAccCloudHealthAwsAccountWithDefaults(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckAwsAccountRegions(api, "cloudhealth_aws_account.account", nil),
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "enabled_regions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthAwsAccount_clearRegions(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	accountName := fmt.Sprintf("account-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthAwsAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthAwsAccountWithRegions(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckAwsAccountRegions(api, "cloudhealth_aws_account.account", []string{"us-east-1"}),
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "enabled_regions.#", "1"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(api) + testAccCloudHealthAwsAccountWithDefaults(accountName),
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckAwsAccountRegions(api, "cloudhealth_aws_account.account", []string{}),
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "enabled_regions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthAwsAccount_clientAPIID(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	accountName := fmt.Sprintf("account-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthAwsAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthAwsAccountWithClientAPIID(accountName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthAwsAccountExists("cloudhealth_aws_account.account"),
+					testUnitCheckTenant(api, "cloudhealth_aws_account.account", "42"),
+				),
+			},
+			{
+				// Updates reach the account in the customer's tenant
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthAwsAccountWithClientAPIID(accountName, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudhealth_aws_account.account", "tags.environment", "second"),
+				),
+			},
+			{
+				Config:              testUnitProviderConfig(api) + testUnitCloudHealthAwsAccountWithClientAPIID(accountName, "second"),
+				ResourceName:        "cloudhealth_aws_account.account",
+				ImportState:         true,
+				ImportStateIdPrefix: "42/",
+				ImportStateVerify:   true,
+			},
+			{
+				// Without the client_api_id the account is looked for in the partner's tenant
+				Config:       testUnitProviderConfig(api) + testUnitCloudHealthAwsAccountWithClientAPIID(accountName, "second"),
+				ResourceName: "cloudhealth_aws_account.account",
+				ImportState:  true,
+				ExpectError:  regexp.MustCompile(`Cannot import non-existent remote object`),
+			},
+		},
+	})
+}
+
 func testAccCheckCloudHealthAwsAccountExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*cloudhealth.Client)
 		for _, r := range s.RootModule().Resources {
+			if r.Type != "cloudhealth_aws_account" {
+				continue
+			}
 			i, _ := strconv.Atoi(r.Primary.ID)
-			if _, err := client.GetAwsAccount(i); err != nil {
+			if _, err := testAccClientFor(r).GetAwsAccount(i); err != nil {
 				return err
 			}
 		}
@@ -41,13 +233,33 @@ func testAccCheckCloudHealthAwsAccountExists(n string) resource.TestCheckFunc {
 	}
 }
 
-func testAccCheckCloudHealthAwsAccountDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*cloudhealth.Client)
+// testUnitCheckAwsAccountRegions checks the regions the fake API has for an
+// AWS Account, where nil means they were never sent.
+func testUnitCheckAwsAccountRegions(api *fakeapi.Server, n string, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		account, ok := api.AwsAccount(r.Primary.ID)
+		if !ok {
+			return fmt.Errorf("AWS Account %s not found", r.Primary.ID)
+		}
+		if !reflect.DeepEqual(account.EnabledRegions, expected) {
+			return fmt.Errorf("Expected enabled_regions %#v, got %#v", expected, account.EnabledRegions)
+		}
+		return nil
+	}
+}
 
+func testAccCheckCloudHealthAwsAccountDestroy(s *terraform.State) error {
 	for _, r := range s.RootModule().Resources {
+		if r.Type != "cloudhealth_aws_account" {
+			continue
+		}
 		i, _ := strconv.Atoi(r.Primary.ID)
-		if _, err := client.GetAwsAccount(i); err != nil {
-			if err == cloudhealth.ErrAwsAccountNotFound {
+		if _, err := testAccClientFor(r).GetAwsAccount(i); err != nil {
+			if errors.Is(err, cloudhealth.ErrAwsAccountNotFound) {
 				continue
 			}
 			return err
@@ -67,3 +279,68 @@ resource "cloudhealth_aws_account" "account" {
 }
 `, r)
 }
+
+func testAccCloudHealthAwsAccountWithCollection(r string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_aws_account" "account" {
+  name = "%[1]s"
+  authentication {
+    protocol = "access_key"
+  }
+
+  cloudtrail {
+    enabled = true
+    bucket  = "%[1]s-cloudtrail"
+  }
+
+  cloudwatch {
+    enabled = true
+  }
+
+  tags = {
+    environment = "test"
+  }
+}
+`, r)
+}
+
+func testUnitCloudHealthAwsAccountWithRegions(r string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_aws_account" "account" {
+  name = "%s"
+  authentication {
+    protocol = "access_key"
+  }
+
+  enabled_regions = ["us-east-1"]
+}
+`, r)
+}
+
+func testUnitCloudHealthAwsAccountWithClientAPIID(r string, environment string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_aws_account" "account" {
+  name          = "%s"
+  client_api_id = 42
+  authentication {
+    protocol = "access_key"
+  }
+
+  tags = {
+    environment = "%s"
+  }
+}
+`, r, environment)
+}
+
+func testAccCloudHealthAwsAccountWithClientAPIID(r string) string {
+	return testAccCloudHealthCustomerWithDefaults(r) + fmt.Sprintf(`
+resource "cloudhealth_aws_account" "account" {
+  name          = "%s"
+  client_api_id = cloudhealth_customer.customer.id
+  authentication {
+    protocol = "access_key"
+  }
+}
+`, r)
+}
@@ -0,0 +1,120 @@
+package cloudhealth
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func resourceCloudHealthAzureSubscription() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudHealthAzureSubscriptionCreate,
+		Read:   resourceCloudHealthAzureSubscriptionRead,
+		Update: resourceCloudHealthAzureSubscriptionUpdate,
+		Delete: resourceCloudHealthAzureSubscriptionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"azure_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceCloudHealthAzureSubscriptionCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutCreate)
+	defer cancel()
+
+	subscription, err := client.CreateAzureSubscriptionWithContext(ctx, cloudhealth.AzureSubscription{
+		Name:     d.Get("name").(string),
+		AzureID:  d.Get("azure_id").(string),
+		TenantID: d.Get("tenant_id").(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(subscription.ID))
+
+	return resourceCloudHealthAzureSubscriptionRead(d, m)
+}
+
+func resourceCloudHealthAzureSubscriptionRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutRead)
+	defer cancel()
+
+	id, _ := strconv.Atoi(d.Id())
+	subscription, err := client.GetAzureSubscriptionWithContext(ctx, id)
+	if errors.Is(err, cloudhealth.ErrAzureSubscriptionNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", subscription.Name)
+	d.Set("azure_id", subscription.AzureID)
+	d.Set("tenant_id", subscription.TenantID)
+
+	return nil
+}
+
+func resourceCloudHealthAzureSubscriptionUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutUpdate)
+	defer cancel()
+
+	id, _ := strconv.Atoi(d.Id())
+	subscription := cloudhealth.AzureSubscription{
+		ID:       id,
+		Name:     d.Get("name").(string),
+		AzureID:  d.Get("azure_id").(string),
+		TenantID: d.Get("tenant_id").(string),
+	}
+
+	updatedSubscription, err := client.UpdateAzureSubscriptionWithContext(ctx, subscription)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(updatedSubscription.ID))
+
+	return resourceCloudHealthAzureSubscriptionRead(d, m)
+}
+
+func resourceCloudHealthAzureSubscriptionDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutDelete)
+	defer cancel()
+
+	id, _ := strconv.Atoi(d.Id())
+	err := client.DeleteAzureSubscriptionWithContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
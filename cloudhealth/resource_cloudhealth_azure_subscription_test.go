@@ -0,0 +1,68 @@
+package cloudhealth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func TestAccCloudHealthAzureSubscription_basic(t *testing.T) {
+	subscriptionName := fmt.Sprintf("subscription-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthAzureSubscriptionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudHealthAzureSubscriptionWithDefaults(subscriptionName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthAzureSubscriptionExists("cloudhealth_azure_subscription.subscription"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudHealthAzureSubscriptionExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*providerMeta).client
+		for _, r := range s.RootModule().Resources {
+			i, _ := strconv.Atoi(r.Primary.ID)
+			if _, err := client.GetAzureSubscription(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckCloudHealthAzureSubscriptionDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*providerMeta).client
+
+	for _, r := range s.RootModule().Resources {
+		i, _ := strconv.Atoi(r.Primary.ID)
+		if _, err := client.GetAzureSubscription(i); err != nil {
+			if errors.Is(err, cloudhealth.ErrAzureSubscriptionNotFound) {
+				continue
+			}
+			return err
+		}
+		return fmt.Errorf("Azure Subscription still exists")
+	}
+	return nil
+}
+
+func testAccCloudHealthAzureSubscriptionWithDefaults(r string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_azure_subscription" "subscription" {
+  name     = "%s"
+  azure_id = "00000000-0000-0000-0000-000000000000"
+}
+`, r)
+}
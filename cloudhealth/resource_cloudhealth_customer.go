@@ -0,0 +1,214 @@
+package cloudhealth
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func resourceCloudHealthCustomer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudHealthCustomerCreate,
+		Read:   resourceCloudHealthCustomerRead,
+		Update: resourceCloudHealthCustomerUpdate,
+		Delete: resourceCloudHealthCustomerDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"classification": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"trial_expiration_date": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"billing_contact": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"address": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"street1": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"street2": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"city": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"zipcode": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"country": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			// The billing settings of the customer. The partner customers
+			// endpoints expose no flex settings, so there is nothing else to
+			// model here.
+			"partner_billing_configuration": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+						"folder": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudHealthCustomerCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutCreate)
+	defer cancel()
+
+	customer, err := client.CreateCustomerWithContext(ctx, convertCustomer(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(customer.ID))
+
+	return resourceCloudHealthCustomerRead(d, m)
+}
+
+func resourceCloudHealthCustomerRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutRead)
+	defer cancel()
+
+	id, _ := strconv.Atoi(d.Id())
+	customer, err := client.GetCustomerWithContext(ctx, id)
+	if errors.Is(err, cloudhealth.ErrCustomerNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", customer.Name)
+	d.Set("classification", customer.Classification)
+	d.Set("trial_expiration_date", customer.TrialExpirationDate)
+	d.Set("billing_contact", customer.BillingContact)
+
+	address := make(map[string]interface{})
+	addressList := make([]map[string]interface{}, 0, 1)
+	address["street1"] = customer.Address.Street1
+	address["street2"] = customer.Address.Street2
+	address["city"] = customer.Address.City
+	address["state"] = customer.Address.State
+	address["zipcode"] = customer.Address.ZipCode
+	address["country"] = customer.Address.Country
+	addressList = append(addressList, address)
+	d.Set("address", addressList)
+
+	billingList := make([]map[string]interface{}, 0, 1)
+	if customer.PartnerBillingConfiguration != nil {
+		billing := make(map[string]interface{})
+		billing["enabled"] = customer.PartnerBillingConfiguration.Enabled
+		billing["folder"] = customer.PartnerBillingConfiguration.Folder
+		billingList = append(billingList, billing)
+	}
+	d.Set("partner_billing_configuration", billingList)
+
+	return nil
+}
+
+func resourceCloudHealthCustomerUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutUpdate)
+	defer cancel()
+
+	id, _ := strconv.Atoi(d.Id())
+	customer := convertCustomer(d)
+	customer.ID = id
+
+	updatedCustomer, err := client.UpdateCustomerWithContext(ctx, customer)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(updatedCustomer.ID))
+
+	return resourceCloudHealthCustomerRead(d, m)
+}
+
+func resourceCloudHealthCustomerDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutDelete)
+	defer cancel()
+
+	id, _ := strconv.Atoi(d.Id())
+	err := client.DeleteCustomerWithContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func convertCustomer(d *schema.ResourceData) cloudhealth.Customer {
+	customer := cloudhealth.Customer{
+		Name:                d.Get("name").(string),
+		Classification:      d.Get("classification").(string),
+		TrialExpirationDate: d.Get("trial_expiration_date").(string),
+		BillingContact:      d.Get("billing_contact").(string),
+		Address: cloudhealth.CustomerAddress{
+			Street1: d.Get("address.0.street1").(string),
+			Street2: d.Get("address.0.street2").(string),
+			City:    d.Get("address.0.city").(string),
+			State:   d.Get("address.0.state").(string),
+			ZipCode: d.Get("address.0.zipcode").(string),
+			Country: d.Get("address.0.country").(string),
+		},
+	}
+
+	if _, ok := d.GetOk("partner_billing_configuration"); ok {
+		customer.PartnerBillingConfiguration = &cloudhealth.CustomerPartnerBillingConfiguration{
+			Enabled: d.Get("partner_billing_configuration.0.enabled").(bool),
+			Folder:  d.Get("partner_billing_configuration.0.folder").(string),
+		}
+	}
+
+	return customer
+}
@@ -0,0 +1,76 @@
+package cloudhealth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func TestAccCloudHealthCustomer_basic(t *testing.T) {
+	customerName := fmt.Sprintf("customer-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthCustomerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudHealthCustomerWithDefaults(customerName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthCustomerExists("cloudhealth_customer.customer"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudHealthCustomerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*providerMeta).client
+		for _, r := range s.RootModule().Resources {
+			i, _ := strconv.Atoi(r.Primary.ID)
+			if _, err := client.GetCustomer(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckCloudHealthCustomerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*providerMeta).client
+
+	for _, r := range s.RootModule().Resources {
+		i, _ := strconv.Atoi(r.Primary.ID)
+		if _, err := client.GetCustomer(i); err != nil {
+			if errors.Is(err, cloudhealth.ErrCustomerNotFound) {
+				continue
+			}
+			return err
+		}
+		return fmt.Errorf("Customer still exists")
+	}
+	return nil
+}
+
+func testAccCloudHealthCustomerWithDefaults(r string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_customer" "customer" {
+  name           = "%s"
+  classification = "managed_without_access"
+
+  address {
+    street1 = "1 Main Street"
+    city    = "Boston"
+    state   = "MA"
+    zipcode = "02110"
+    country = "USA"
+  }
+}
+`, r)
+}
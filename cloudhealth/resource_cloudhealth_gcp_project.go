@@ -0,0 +1,145 @@
+package cloudhealth
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func resourceCloudHealthGcpProject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudHealthGcpProjectCreate,
+		Read:   resourceCloudHealthGcpProjectRead,
+		Update: resourceCloudHealthGcpProjectUpdate,
+		Delete: resourceCloudHealthGcpProjectDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"project_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"credentials": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_email": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						// CloudHealth never returns the private key, so it is
+						// kept as configured rather than read back.
+						"private_key": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCloudHealthGcpProjectCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutCreate)
+	defer cancel()
+
+	project, err := client.CreateGcpProjectWithContext(ctx, cloudhealth.GcpProject{
+		Name:      d.Get("name").(string),
+		ProjectID: d.Get("project_id").(string),
+		Credentials: cloudhealth.GcpProjectCredentials{
+			ClientEmail: d.Get("credentials.0.client_email").(string),
+			PrivateKey:  d.Get("credentials.0.private_key").(string),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(project.ID))
+
+	return resourceCloudHealthGcpProjectRead(d, m)
+}
+
+func resourceCloudHealthGcpProjectRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutRead)
+	defer cancel()
+
+	id, _ := strconv.Atoi(d.Id())
+	project, err := client.GetGcpProjectWithContext(ctx, id)
+	if errors.Is(err, cloudhealth.ErrGcpProjectNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", project.Name)
+	d.Set("project_id", project.ProjectID)
+	credentials := make(map[string]interface{})
+	credentialsList := make([]map[string]interface{}, 0, 1)
+	credentials["client_email"] = project.Credentials.ClientEmail
+	credentials["private_key"] = d.Get("credentials.0.private_key").(string)
+	credentialsList = append(credentialsList, credentials)
+	d.Set("credentials", credentialsList)
+
+	return nil
+}
+
+func resourceCloudHealthGcpProjectUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutUpdate)
+	defer cancel()
+
+	id, _ := strconv.Atoi(d.Id())
+	project := cloudhealth.GcpProject{
+		ID:        id,
+		Name:      d.Get("name").(string),
+		ProjectID: d.Get("project_id").(string),
+		Credentials: cloudhealth.GcpProjectCredentials{
+			ClientEmail: d.Get("credentials.0.client_email").(string),
+			PrivateKey:  d.Get("credentials.0.private_key").(string),
+		},
+	}
+
+	updatedProject, err := client.UpdateGcpProjectWithContext(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(updatedProject.ID))
+
+	return resourceCloudHealthGcpProjectRead(d, m)
+}
+
+func resourceCloudHealthGcpProjectDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*providerMeta).client
+	ctx, cancel := operationContext(d, m, schema.TimeoutDelete)
+	defer cancel()
+
+	id, _ := strconv.Atoi(d.Id())
+	err := client.DeleteGcpProjectWithContext(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package cloudhealth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+func TestAccCloudHealthGcpProject_basic(t *testing.T) {
+	projectName := fmt.Sprintf("project-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthGcpProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudHealthGcpProjectWithDefaults(projectName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthGcpProjectExists("cloudhealth_gcp_project.project"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudHealthGcpProjectExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*providerMeta).client
+		for _, r := range s.RootModule().Resources {
+			i, _ := strconv.Atoi(r.Primary.ID)
+			if _, err := client.GetGcpProject(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckCloudHealthGcpProjectDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*providerMeta).client
+
+	for _, r := range s.RootModule().Resources {
+		i, _ := strconv.Atoi(r.Primary.ID)
+		if _, err := client.GetGcpProject(i); err != nil {
+			if errors.Is(err, cloudhealth.ErrGcpProjectNotFound) {
+				continue
+			}
+			return err
+		}
+		return fmt.Errorf("GCP Project still exists")
+	}
+	return nil
+}
+
+func testAccCloudHealthGcpProjectWithDefaults(r string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_gcp_project" "project" {
+  name       = "%s"
+  project_id = "%s"
+
+  credentials {
+    client_email = "cloudhealth@%s.iam.gserviceaccount.com"
+  }
+}
+`, r, r, r)
+}
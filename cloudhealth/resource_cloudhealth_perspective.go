@@ -1,13 +1,34 @@
 package cloudhealth
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
 )
 
+const (
+	perspectiveOnDestroyHardDelete = "hard_delete"
+	perspectiveOnDestroyArchive    = "archive"
+)
+
+var (
+	perspectiveGroupTypes   = []string{"filter", "categorize"}
+	perspectiveCombineWiths = []string{"AND", "OR"}
+	perspectiveClauseOps    = []string{
+		"=", "!=", ">", "<", ">=", "<=",
+		"Contains", "Does Not Contain",
+		"Starts With", "Does Not Start With",
+		"Ends With", "Does Not End With",
+	}
+)
+
 func resourceCloudHealthPerspective() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceCloudHealthPerspectiveCreate,
@@ -15,101 +36,195 @@ func resourceCloudHealthPerspective() *schema.Resource {
 		Update: resourceCloudHealthPerspectiveUpdate,
 		Delete: resourceCloudHealthPerspectiveDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importStateWithClientAPIID,
 		},
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: false,
+		Timeouts:      resourceTimeouts(),
+		CustomizeDiff: customizePerspectiveDiff,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceCloudHealthPerspectiveV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceCloudHealthPerspectiveStateUpgradeV0,
 			},
-			"include_in_reports": {
-				Type:     schema.TypeBool,
-				Required: true,
-				ForceNew: false,
-			},
-			"group": {
-				Type:     schema.TypeList,
-				Optional: true,
-				ForceNew: false,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-							ForceNew: false,
-						},
-						"ref_id": {
-							Type:     schema.TypeString,
-							ForceNew: false,
-							Computed: true,
-							Optional: true,
+		},
+		Schema: resourceCloudHealthPerspectiveSchema(),
+	}
+}
+
+func resourceCloudHealthPerspectiveSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"client_api_id": clientAPIIDSchema(),
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: false,
+		},
+		"include_in_reports": {
+			Type:     schema.TypeBool,
+			Required: true,
+			ForceNew: false,
+		},
+		// What happens to the perspective on destroy. Archived perspectives
+		// keep their history.
+		"on_destroy": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     false,
+			Default:      perspectiveOnDestroyHardDelete,
+			ValidateFunc: validateStringInSlice([]string{perspectiveOnDestroyHardDelete, perspectiveOnDestroyArchive}),
+		},
+		// Whether create takes over an archived perspective with the same
+		// name instead of failing
+		"adopt_archived": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			ForceNew: false,
+			Default:  false,
+		},
+		"group": {
+			Type:     schema.TypeList,
+			Optional: true,
+			ForceNew: false,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+						ForceNew: false,
+					},
+					// Stable identity of the group: a keyed group keeps
+					// its ref_id however the list is reordered or the
+					// group renamed
+					"key": {
+						Type:     schema.TypeString,
+						Optional: true,
+						ForceNew: false,
+					},
+					"ref_id": {
+						Type:     schema.TypeString,
+						ForceNew: false,
+						Computed: true,
+						Optional: true,
+					},
+					"type": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ForceNew:     false,
+						Default:      "filter",
+						ValidateFunc: validateStringInSlice(perspectiveGroupTypes),
+					},
+					// for type="categorize": the groups CloudHealth
+					// discovered, one per distinct value
+					"dynamic_groups": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"ref_id": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"name": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+								"val": {
+									Type:     schema.TypeString,
+									Computed: true,
+								},
+							},
 						},
-						"type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ForceNew: false,
-							Default:  "filter",
+					},
+					// for type="categorize": display names for discovered values
+					"dynamic_group": {
+						Type:     schema.TypeSet,
+						Optional: true,
+						ForceNew: false,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"val": {
+									Type:     schema.TypeString,
+									Required: true,
+									ForceNew: false,
+								},
+								"name": {
+									Type:     schema.TypeString,
+									Required: true,
+									ForceNew: false,
+								},
+							},
 						},
-						"rule": {
-							Type:     schema.TypeList,
-							Optional: true,
-							ForceNew: false,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"asset": {
-										Type:     schema.TypeString,
-										Required: true,
-										ForceNew: false,
-									},
-									// for type="categorize"
-									"tag_field": {
-										Type:     schema.TypeList,
-										Optional: true,
-										ForceNew: false,
-										Elem:     &schema.Schema{Type: schema.TypeString},
-									},
-									// for type="categorize"
-									"field": {
-										Type:     schema.TypeList,
-										Optional: true,
-										ForceNew: false,
-										Elem:     &schema.Schema{Type: schema.TypeString},
-									},
-									"combine_with": {
-										Type:     schema.TypeString,
-										Optional: true,
-										ForceNew: false,
-									},
-									"condition": {
-										Type:     schema.TypeList,
-										Optional: true,
-										ForceNew: false,
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"tag_field": {
-													Type:     schema.TypeList,
-													Optional: true,
-													ForceNew: false,
-													Elem:     &schema.Schema{Type: schema.TypeString},
-												},
-												"field": {
-													Type:     schema.TypeList,
-													Optional: true,
-													ForceNew: false,
-													Elem:     &schema.Schema{Type: schema.TypeString},
-												},
-												"op": {
-													Type:     schema.TypeString,
-													Optional: true,
-													ForceNew: false,
-													Default:  "=",
-												},
-												"val": {
-													Type:     schema.TypeString,
-													Optional: true,
-													ForceNew: false,
-												},
+					},
+					"rule": {
+						Type:     schema.TypeList,
+						Optional: true,
+						ForceNew: false,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"asset": {
+									Type:     schema.TypeString,
+									Required: true,
+									ForceNew: false,
+								},
+								// Position of the rule among the rules of all
+								// groups; lower goes first. Rules without one
+								// follow, in the order of their groups.
+								"priority": {
+									Type:         schema.TypeInt,
+									Optional:     true,
+									ForceNew:     false,
+									ValidateFunc: validateIntAtLeast(1),
+								},
+								// for type="categorize"
+								"tag_field": {
+									Type:     schema.TypeList,
+									Optional: true,
+									ForceNew: false,
+									Elem:     &schema.Schema{Type: schema.TypeString},
+								},
+								// for type="categorize"
+								"field": {
+									Type:     schema.TypeList,
+									Optional: true,
+									ForceNew: false,
+									Elem:     &schema.Schema{Type: schema.TypeString},
+								},
+								"combine_with": {
+									Type:         schema.TypeString,
+									Optional:     true,
+									ForceNew:     false,
+									ValidateFunc: validateStringInSlice(perspectiveCombineWiths),
+								},
+								"condition": {
+									Type:     schema.TypeList,
+									Optional: true,
+									ForceNew: false,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"tag_field": {
+												Type:     schema.TypeList,
+												Optional: true,
+												ForceNew: false,
+												Elem:     &schema.Schema{Type: schema.TypeString},
+											},
+											"field": {
+												Type:     schema.TypeList,
+												Optional: true,
+												ForceNew: false,
+												Elem:     &schema.Schema{Type: schema.TypeString},
+											},
+											"op": {
+												Type:         schema.TypeString,
+												Optional:     true,
+												ForceNew:     false,
+												Default:      "=",
+												ValidateFunc: validateStringInSlice(perspectiveClauseOps),
+											},
+											"val": {
+												Type:     schema.TypeString,
+												Optional: true,
+												ForceNew: false,
 											},
 										},
 									},
@@ -119,47 +234,100 @@ func resourceCloudHealthPerspective() *schema.Resource {
 					},
 				},
 			},
-			"constant": {
-				Type:     schema.TypeList,
-				Optional: true,
-				ForceNew: false,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"constant_type": {
-							Type:     schema.TypeString,
-							ForceNew: false,
-							Computed: true,
-						},
-						"ref_id": {
-							Type:     schema.TypeString,
-							ForceNew: false,
-							Computed: true,
-						},
-						"blk_id": {
-							Type:     schema.TypeString,
-							ForceNew: false,
-							Computed: true,
-							Optional: true,
-						},
-						"name": {
-							Type:     schema.TypeString,
-							ForceNew: false,
-							Computed: true,
-							Optional: true,
-						},
-						"val": {
-							Type:     schema.TypeString,
-							ForceNew: false,
-							Computed: true,
-							Optional: true,
-						},
-						"is_other": {
-							Type:     schema.TypeString,
-							ForceNew: false,
-							Computed: true,
-							Optional: true,
-						},
+		},
+		// The "Other" Static Group collecting the assets no group matched.
+		// CloudHealth names it "Other" unless told otherwise.
+		"other_group": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			ForceNew: false,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+						ForceNew: false,
+					},
+				},
+			},
+		},
+		"merge": {
+			Type:     schema.TypeList,
+			Optional: true,
+			ForceNew: false,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:     schema.TypeString,
+						Optional: true,
+						ForceNew: false,
+						Default:  cloudhealth.DynamicGroupType,
+					},
+					// ref_id of the constant the others are merged into
+					"to": {
+						Type:     schema.TypeString,
+						Required: true,
+						ForceNew: false,
+					},
+					// ref_ids of the constants being merged
+					"from": {
+						Type:     schema.TypeList,
+						Required: true,
+						ForceNew: false,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		// ref_id pinned to each group, by key or, for groups without
+		// one, by name
+		"group_ref_ids": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"constant": {
+			Type:     schema.TypeList,
+			Optional: true,
+			ForceNew: false,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"constant_type": {
+						Type:     schema.TypeString,
+						ForceNew: false,
+						Computed: true,
+					},
+					"ref_id": {
+						Type:     schema.TypeString,
+						ForceNew: false,
+						Computed: true,
+					},
+					"blk_id": {
+						Type:     schema.TypeString,
+						ForceNew: false,
+						Computed: true,
+						Optional: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						ForceNew: false,
+						Computed: true,
+						Optional: true,
+					},
+					"val": {
+						Type:     schema.TypeString,
+						ForceNew: false,
+						Computed: true,
+						Optional: true,
+					},
+					"is_other": {
+						Type:     schema.TypeString,
+						ForceNew: false,
+						Computed: true,
+						Optional: true,
 					},
 				},
 			},
@@ -167,15 +335,60 @@ func resourceCloudHealthPerspective() *schema.Resource {
 	}
 }
 
+// resourceCloudHealthPerspectiveV0 is the schema of the perspective before
+// groups could be keyed and their ref_ids pinned in state.
+func resourceCloudHealthPerspectiveV0() *schema.Resource {
+	s := resourceCloudHealthPerspectiveSchema()
+	delete(s, "group_ref_ids")
+	delete(s, "other_group")
+	groupSchema := s["group"].Elem.(*schema.Resource).Schema
+	delete(groupSchema, "key")
+	delete(groupSchema["rule"].Elem.(*schema.Resource).Schema, "priority")
+	return &schema.Resource{Schema: s}
+}
+
+// resourceCloudHealthPerspectiveStateUpgradeV0 pins the ref_ids of the groups
+// in existing state by name, which is how they were identified until then.
+func resourceCloudHealthPerspectiveStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	refIDs := make(map[string]interface{})
+	groups, _ := rawState["group"].([]interface{})
+	for _, g := range groups {
+		g, ok := g.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := g["name"].(string)
+		refID, _ := g["ref_id"].(string)
+		if name != "" && refID != "" {
+			refIDs[name] = refID
+		}
+	}
+	rawState["group_ref_ids"] = refIDs
+	return rawState, nil
+}
+
 func resourceCloudHealthPerspectiveCreate(d *schema.ResourceData, m interface{}) error {
 	var createdId string
-	client := m.(*cloudhealth.Client)
-	perspective, err := convertPerspective(d)
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutCreate)
+	defer cancel()
+
+	if d.Get("adopt_archived").(bool) {
+		archivedId, err := findArchivedPerspectiveID(ctx, client, d.Get("name").(string))
+		if err != nil {
+			return fmt.Errorf("Could not look for archived perspectives: %v", err)
+		}
+		if archivedId != "" {
+			return adoptArchivedPerspective(ctx, archivedId, d, m)
+		}
+	}
+
+	perspective, err := convertPerspective(d, discoveredDynamicGroups(d))
 	if err != nil {
 		return fmt.Errorf("Could not convert perspective: %v", err)
 	}
 
-	createdId, err = client.CreatePerspective(perspective)
+	createdId, err = client.CreatePerspectiveWithContext(ctx, perspective)
 	if err != nil {
 		return fmt.Errorf("Could not create perspective: %v", err)
 	}
@@ -187,16 +400,92 @@ func resourceCloudHealthPerspectiveCreate(d *schema.ResourceData, m interface{})
 	return resourceCloudHealthPerspectiveRead(d, m)
 }
 
+// adoptArchivedPerspective brings an archived perspective back under
+// management instead of creating a new one with the same name, which
+// CloudHealth would reject. The archived constants are used to reconcile
+// ref_ids so groups keep their history.
+func adoptArchivedPerspective(ctx context.Context, id string, d *schema.ResourceData, m interface{}) error {
+	client := clientFor(d, m)
+
+	dynamicGroups := make(map[string][]interface{})
+	archived, err := client.GetPerspectiveWithContext(ctx, id)
+	switch {
+	case err == nil:
+		err = d.Set("constant", buildConstants(archived))
+		if err != nil {
+			return err
+		}
+		dynamicGroups = buildDynamicGroups(archived)
+	case errors.Is(err, cloudhealth.ErrPerspectiveNotFound):
+	default:
+		return fmt.Errorf("Error when reading archived perspective %s: %v", id, err)
+	}
+
+	perspective, err := convertPerspective(d, dynamicGroups)
+	if err != nil {
+		return fmt.Errorf("Could not convert perspective: %v", err)
+	}
+
+	// Updating an archived perspective makes it active again
+	_, err = client.UpdatePerspectiveWithContext(ctx, id, perspective)
+	if err != nil {
+		return fmt.Errorf("Could not unarchive perspective %s: %v", id, err)
+	}
+
+	d.SetId(id)
+	return resourceCloudHealthPerspectiveRead(d, m)
+}
+
+// findArchivedPerspectiveID returns the ID of the archived perspective with the
+// given name, or an empty string if there is none. Several matches are an
+// error rather than a guess.
+func findArchivedPerspectiveID(ctx context.Context, client *cloudhealth.Client, name string) (string, error) {
+	perspectiveMap, err := client.GetAllPerspectivesWithContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+	for _, id := range sortedPerspectiveIDs(*perspectiveMap) {
+		status := (*perspectiveMap)[id]
+		if !status.Active && status.Name == name {
+			ids = append(ids, id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", nil
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d archived perspectives found with name %q: %v", len(ids), name, ids)
+	}
+}
+
 func resourceCloudHealthPerspectiveRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*cloudhealth.Client)
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutRead)
+	defer cancel()
 
 	id := d.Id()
-	perspective, err := client.GetPerspective(id)
+	perspective, err := client.GetPerspectiveWithContext(ctx, id)
 
-	switch err {
-	case nil:
-		return buildPerspective(perspective, d)
-	case cloudhealth.ErrPerspectiveNotFound:
+	switch {
+	case err == nil:
+		// Imported perspectives have no on_destroy or adopt_archived yet
+		if _, ok := d.GetOk("on_destroy"); !ok {
+			d.Set("on_destroy", perspectiveOnDestroyHardDelete)
+		}
+		if _, ok := d.GetOkExists("adopt_archived"); !ok {
+			d.Set("adopt_archived", false)
+		}
+		err = buildPerspective(perspective, d)
+		if err != nil {
+			return err
+		}
+		return d.Set("group_ref_ids", groupRefIDs(getArray(d, "group")))
+	case errors.Is(err, cloudhealth.ErrPerspectiveNotFound):
 		d.SetId("")
 		return nil
 	default:
@@ -205,15 +494,17 @@ func resourceCloudHealthPerspectiveRead(d *schema.ResourceData, m interface{}) e
 }
 
 func resourceCloudHealthPerspectiveUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*cloudhealth.Client)
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutUpdate)
+	defer cancel()
 
 	id := d.Id()
-	perspective, err := convertPerspective(d)
+	perspective, err := convertPerspective(d, discoveredDynamicGroups(d))
 	if err != nil {
 		return fmt.Errorf("Could not convert perspective: %v", err)
 	}
 
-	_, err = client.UpdatePerspective(id, perspective)
+	_, err = client.UpdatePerspectiveWithContext(ctx, id, perspective)
 	if err != nil {
 		return fmt.Errorf("Could not create perspective: %v", err)
 	}
@@ -225,8 +516,16 @@ func resourceCloudHealthPerspectiveUpdate(d *schema.ResourceData, m interface{})
 }
 
 func resourceCloudHealthPerspectiveDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*cloudhealth.Client)
-	err := client.DeletePerspective(d.Id())
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutDelete)
+	defer cancel()
+
+	var err error
+	if d.Get("on_destroy").(string) == perspectiveOnDestroyArchive {
+		err = client.ArchivePerspectiveWithContext(ctx, d.Id())
+	} else {
+		err = client.DeletePerspectiveWithContext(ctx, d.Id())
+	}
 	if err != nil {
 		return err
 	}
@@ -236,7 +535,103 @@ func resourceCloudHealthPerspectiveDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func convertPerspective(d *schema.ResourceData) (perspective *cloudhealth.Perspective, err error) {
+// customizePerspectiveDiff validates the planned groups and their rules. It
+// holds the checks that involve several fields, which the schema's
+// ValidateFuncs can't express, so unlike those they run at plan time rather
+// than on terraform validate.
+func customizePerspectiveDiff(d *schema.ResourceDiff, m interface{}) error {
+	if err := validatePerspectiveGroupKeys(d, m); err != nil {
+		return err
+	}
+	return validatePerspectiveRules(d, m)
+}
+
+// validatePerspectiveGroupKeys checks that no two groups share an identity.
+// Groups without a key are identified by their name.
+func validatePerspectiveGroupKeys(d *schema.ResourceDiff, m interface{}) error {
+	var problems []string
+	seen := make(map[string]int)
+	keyed := make(map[int]bool)
+	for groupIdx, g := range d.Get("group").([]interface{}) {
+		group, ok := g.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		keyPath := fmt.Sprintf("group.%d.key", groupIdx)
+		if !d.NewValueKnown(keyPath) || !d.NewValueKnown(fmt.Sprintf("group.%d.name", groupIdx)) {
+			continue
+		}
+		keyed[groupIdx] = group["key"].(string) != ""
+		identity := groupIdentity(group)
+		if other, ok := seen[identity]; ok && (keyed[groupIdx] || keyed[other]) {
+			problems = append(problems, fmt.Sprintf("%s: %q already identifies %s", formatPath(keyPath), identity, formatPath(fmt.Sprintf("group.%d", other))))
+			continue
+		}
+		seen[identity] = groupIdx
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("Invalid perspective groups:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// validatePerspectiveRules catches rules CloudHealth would reject that can't
+// be checked field by field, pointing at the offending block.
+func validatePerspectiveRules(d *schema.ResourceDiff, m interface{}) error {
+	var problems []string
+	for groupIdx, g := range d.Get("group").([]interface{}) {
+		group, ok := g.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		lastPriority, unprioritized := 0, false
+		for ruleIdx, r := range group["rule"].([]interface{}) {
+			rule, ok := r.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			rulePath := fmt.Sprintf("group.%d.rule.%d", groupIdx, ruleIdx)
+
+			// CloudHealth keeps no order of its own for the rules of a group
+			if d.NewValueKnown(rulePath + ".priority") {
+				switch priority := rule["priority"].(int); {
+				case priority == 0:
+					unprioritized = true
+				case priority < lastPriority || unprioritized:
+					problems = append(problems, fmt.Sprintf("%s: the rules of a group must be listed in priority order, rules without a priority last", formatPath(rulePath)))
+				default:
+					lastPriority = priority
+				}
+			}
+
+			if group["type"] == "categorize" &&
+				len(rule["field"].([]interface{})) == 0 && len(rule["tag_field"].([]interface{})) == 0 &&
+				d.NewValueKnown(rulePath+".field") && d.NewValueKnown(rulePath+".tag_field") {
+				problems = append(problems, fmt.Sprintf("%s: a categorize rule needs a field or tag_field", formatPath(rulePath)))
+			}
+
+			for conditionIdx, c := range rule["condition"].([]interface{}) {
+				condition, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if len(condition["field"].([]interface{})) > 0 && len(condition["tag_field"].([]interface{})) > 0 {
+					conditionPath := fmt.Sprintf("%s.condition.%d", rulePath, conditionIdx)
+					problems = append(problems, fmt.Sprintf("%s: only one of field and tag_field can be set", formatPath(conditionPath)))
+				}
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("Invalid perspective rules:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// convertPerspective builds the API representation of the perspective in d.
+// dynamicGroups holds the Dynamic Groups CloudHealth has discovered so far,
+// keyed by the ref_id of their categorize group.
+func convertPerspective(d *schema.ResourceData, dynamicGroups map[string][]interface{}) (perspective *cloudhealth.Perspective, err error) {
 	constants := []*cloudhealth.Constant{
 		cloudhealth.NewConstant(cloudhealth.StaticGroupType),
 		cloudhealth.NewConstant(cloudhealth.DynamicGroupType),
@@ -260,9 +655,11 @@ func convertPerspective(d *schema.ResourceData) (perspective *cloudhealth.Perspe
 
 	tfGroups := getArray(d, "group")
 	tfConstants := getArray(d, "constant")
+	var priorities []int
 
 	if len(tfGroups) > 0 {
-		err = fixRefIDs(tfGroups, tfConstants)
+		pinned := d.Get("group_ref_ids").(map[string]interface{})
+		err = fixRefIDs(tfGroups, tfConstants, dynamicGroups, pinned)
 		if err != nil {
 			return nil, err
 		}
@@ -283,7 +680,7 @@ func convertPerspective(d *schema.ResourceData) (perspective *cloudhealth.Perspe
 
 		if tfGroup["type"].(string) == "categorize" {
 			// Convert any dynamic groups for this group (if it's a Dynamic Group Block)
-			dynamicGroupConstantItems := convertDynamicGroupConstantItems(refId, tfConstants)
+			dynamicGroupConstantItems := convertDynamicGroupConstantItems(refId, dynamicGroups[refId], tfGroup["dynamic_group"].(*schema.Set).List())
 			constantsByType[cloudhealth.DynamicGroupType].List = append(constantsByType[cloudhealth.DynamicGroupType].List, dynamicGroupConstantItems...)
 			constantType = cloudhealth.DynamicGroupBlockType
 		} else if tfGroup["type"].(string) == "filter" {
@@ -293,11 +690,15 @@ func convertPerspective(d *schema.ResourceData) (perspective *cloudhealth.Perspe
 		}
 
 		// Convert any rules
-		rules, err := convertRules(refId, name, groupType, tfGroup["rule"].([]interface{}))
+		tfRules := tfGroup["rule"].([]interface{})
+		rules, err := convertRules(refId, name, groupType, tfRules)
 		if err != nil {
 			return nil, err
 		}
 		perspective.Schema.Rules = append(perspective.Schema.Rules, rules...)
+		for _, r := range tfRules {
+			priorities = append(priorities, r.(map[string]interface{})["priority"].(int))
+		}
 
 		// Add a constant for this group
 		constantItem := cloudhealth.ConstantItem{
@@ -308,7 +709,14 @@ func convertPerspective(d *schema.ResourceData) (perspective *cloudhealth.Perspe
 		constant.List = append(constant.List, constantItem)
 	}
 
-	err = addOtherConstants(tfConstants, constantsByType)
+	// CloudHealth applies the rules of all groups in a single list
+	rules := make([]cloudhealth.Rule, len(perspective.Schema.Rules))
+	for idx, ruleIdx := range rulePriorityOrder(priorities) {
+		rules[idx] = perspective.Schema.Rules[ruleIdx]
+	}
+	perspective.Schema.Rules = rules
+
+	err = addOtherConstants(tfConstants, constantsByType, d.Get("other_group.0.name").(string))
 	if err != nil {
 		return nil, err
 	}
@@ -319,11 +727,24 @@ func convertPerspective(d *schema.ResourceData) (perspective *cloudhealth.Perspe
 			perspective.Schema.Constants = append(perspective.Schema.Constants, *constantGroup)
 		}
 	}
-	perspective.Schema.Merges = make([]interface{}, 0)
+	perspective.Schema.Merges = convertMerges(getArray(d, "merge"))
 	return perspective, nil
 }
 
-func fixRefIDs(groups []interface{}, constants []interface{}) error {
+func convertMerges(merges []interface{}) []cloudhealth.Merge {
+	result := make([]cloudhealth.Merge, len(merges))
+	for idx, m := range merges {
+		m := m.(map[string]interface{})
+		result[idx] = cloudhealth.Merge{
+			Type: stringOrNil(m["type"]),
+			To:   stringOrNil(m["to"]),
+			From: convertStringArray(m["from"]),
+		}
+	}
+	return result
+}
+
+func fixRefIDs(groups []interface{}, constants []interface{}, dynamicGroups map[string][]interface{}, pinned map[string]interface{}) error {
 	/* This is to reconcile the ref_id on groups with the ones in constants.
 
 	   Groups are an ordered list and yet also identified by their ref_id.
@@ -331,7 +752,9 @@ func fixRefIDs(groups []interface{}, constants []interface{}) error {
 	   use a list. When groups are reordered, the computed ref_id fields stay put;
 	   they do not follow the rest of the groups contents.
 
-	   So we use the "constants" structure to reconcile these situations.
+	   Groups with a key get the ref_id pinned to that key in group_ref_ids,
+	   however the list was edited. The other groups are identified by name,
+	   through group_ref_ids and the "constants" structure.
 
 	   If the group is renamed in-place, the new name won't have an entry in
 	   constants, so it's presumed to keep its ref_id.
@@ -339,84 +762,204 @@ func fixRefIDs(groups []interface{}, constants []interface{}) error {
 	   If the group is re-ordered, we look up the ref_ids by the name in the
 	   constants structure.
 
-	   If you both re-order and re-name, it will correct the ref_ids of all the
-	   other groups, but the reordered group with the new name will be given a
-	   new ref_id
+	   If you both re-order and re-name a group without a key, it will correct
+	   the ref_ids of all the other groups, but the reordered group with the new
+	   name will be given a new ref_id
+
+	   Dynamic Groups are not groups in the schema, but they share the ref_id
+	   space so new ref_ids must not collide with them either.
 	*/
 
 	refIdByNameFromConstants := make(map[string]string)
 	maxRefId := 0
+	bumpMaxRefId := func(refId string, what string) error {
+		refIdInt, err := strconv.Atoi(refId)
+		if err != nil {
+			return fmt.Errorf("%s with non integer ref_id: %s", what, refId)
+		}
+		if refIdInt >= maxRefId {
+			maxRefId = refIdInt + 1
+		}
+		return nil
+	}
 	for _, c := range constants {
 		c := c.(map[string]interface{})
 		refIdByNameFromConstants[c["name"].(string)] = c["ref_id"].(string)
-		constantRefIdInt, err := strconv.Atoi(c["ref_id"].(string))
-		if err != nil {
-			return fmt.Errorf("Group with non integer ref_id: %s", c["ref_id"])
+		if err := bumpMaxRefId(c["ref_id"].(string), "Group"); err != nil {
+			return err
+		}
+	}
+	for _, dynamicGroupsForBlock := range dynamicGroups {
+		for _, dg := range dynamicGroupsForBlock {
+			dg := dg.(map[string]interface{})
+			if err := bumpMaxRefId(dg["ref_id"].(string), "Dynamic group"); err != nil {
+				return err
+			}
 		}
-		if constantRefIdInt >= maxRefId {
-			maxRefId = constantRefIdInt + 1
+	}
+	for identity, refId := range pinned {
+		if err := bumpMaxRefId(refId.(string), fmt.Sprintf("Group %s", identity)); err != nil {
+			return err
 		}
 	}
 	usedRefIds := make(map[string]bool)
+	fixed := make([]bool, len(groups))
 
-	// Go through and apply the ref_id from the constant to anything that matches the same name in the group
-	for _, g := range groups {
+	// Apply the pinned ref_ids first so that they win over names
+	for idx, g := range groups {
+		g := g.(map[string]interface{})
+		pinnedRefId, ok := pinned[groupIdentity(g)].(string)
+		if !ok || usedRefIds[pinnedRefId] {
+			continue
+		}
+		g["ref_id"] = pinnedRefId
+		usedRefIds[pinnedRefId] = true
+		fixed[idx] = true
+	}
+
+	// Go through and apply the ref_id from the constant to anything that
+	// matches the same name in the group, unless a keyed group has taken it
+	fixedNames := make(map[string]bool)
+	for idx, g := range groups {
 		g := g.(map[string]interface{})
 		groupName := g["name"].(string)
+		if fixed[idx] {
+			fixedNames[groupName] = true
+			continue
+		}
 		if constantRefId, ok := refIdByNameFromConstants[groupName]; ok {
-			g["ref_id"] = constantRefId
-			if usedRefIds[constantRefId] == true {
+			if fixedNames[groupName] {
 				return fmt.Errorf("Two groups with the same name: %s", groupName)
 			}
+			fixedNames[groupName] = true
+			if usedRefIds[constantRefId] {
+				continue
+			}
+			g["ref_id"] = constantRefId
 			usedRefIds[constantRefId] = true
+			fixed[idx] = true
 		}
 	}
 
-	// Now for any group who name is not in constants, either use its exising
-	// ref_id (we assume this meant a rename) or, if it doesn't have one,
-	// generate a unique one
-	for _, g := range groups {
+	// Now for any group not fixed above, either use its exising ref_id (we
+	// assume this meant a rename) or, if it doesn't have one, generate a
+	// unique one
+	for idx, g := range groups {
 		g := g.(map[string]interface{})
-		groupName := g["name"].(string)
-		if _, inConstants := refIdByNameFromConstants[groupName]; inConstants {
-			// Already fixed ref_id above
+		if fixed[idx] {
 			continue
 		}
 
 		groupRefId := g["ref_id"].(string)
 		if groupRefId != "" && usedRefIds[groupRefId] == false {
 			// Group was renamed; stick with the existing groupRefId
+			usedRefIds[groupRefId] = true
 			continue
 		}
 
 		// Group is new - assign a new ref id
 		// Must be an integer that is not already in use
 		g["ref_id"] = strconv.Itoa(maxRefId)
+		usedRefIds[g["ref_id"].(string)] = true
 		maxRefId++
 	}
 
 	return nil
 }
 
-func convertDynamicGroupConstantItems(groupRefId string, constants []interface{}) []cloudhealth.ConstantItem {
+// groupIdentity returns what identifies a group across edits: its key, or its
+// name if it has none.
+func groupIdentity(group map[string]interface{}) string {
+	if key, _ := group["key"].(string); key != "" {
+		return key
+	}
+	return group["name"].(string)
+}
+
+// groupRefIDs returns the ref_id of each group by its identity, to pin them
+// in state.
+func groupRefIDs(groups []interface{}) map[string]interface{} {
+	refIDs := make(map[string]interface{})
+	for _, g := range groups {
+		g := g.(map[string]interface{})
+		refIDs[groupIdentity(g)] = g["ref_id"]
+	}
+	return refIDs
+}
+
+// convertDynamicGroupConstantItems emits the Dynamic Group constants of a
+// categorize group. Discovered groups are named after their value unless a
+// dynamic_group block gives them a display name. Overrides for values that
+// CloudHealth hasn't discovered yet are kept in state, see
+// keepPendingDynamicGroupOverrides, and applied once the value shows up.
+func convertDynamicGroupConstantItems(groupRefId string, discovered []interface{}, overrides []interface{}) []cloudhealth.ConstantItem {
 	result := make([]cloudhealth.ConstantItem, 0)
 
-	for _, c := range constants {
-		c := c.(map[string]interface{})
-		if c["blk_id"] != groupRefId {
-			continue
+	nameByVal := make(map[string]string)
+	for _, o := range overrides {
+		o := o.(map[string]interface{})
+		nameByVal[o["val"].(string)] = o["name"].(string)
+	}
+
+	for _, dg := range discovered {
+		dg := dg.(map[string]interface{})
+		val := dg["val"].(string)
+		name, ok := nameByVal[val]
+		if !ok {
+			name = val
 		}
 		blk_id := groupRefId
 		result = append(result, cloudhealth.ConstantItem{
-			Name:  c["name"].(string),
-			RefID: c["ref_id"].(string),
+			Name:  name,
+			RefID: dg["ref_id"].(string),
 			BlkID: &blk_id,
-			Val:   c["val"].(string),
+			Val:   val,
 		})
 	}
 	return result
 }
 
+// discoveredDynamicGroups returns the Dynamic Groups known from the prior
+// state keyed by the ref_id of their categorize group. They are taken from the
+// prior state rather than the planned groups so that they follow their group
+// when groups are reordered.
+func discoveredDynamicGroups(d *schema.ResourceData) map[string][]interface{} {
+	result := make(map[string][]interface{})
+	seen := make(map[string]bool)
+
+	add := func(blkId string, dg map[string]interface{}) {
+		refId := dg["ref_id"].(string)
+		if seen[refId] {
+			return
+		}
+		seen[refId] = true
+		result[blkId] = append(result[blkId], dg)
+	}
+
+	old, _ := d.GetChange("group")
+	for _, g := range old.([]interface{}) {
+		g := g.(map[string]interface{})
+		dynamicGroups, _ := g["dynamic_groups"].([]interface{})
+		for _, dg := range dynamicGroups {
+			add(g["ref_id"].(string), dg.(map[string]interface{}))
+		}
+	}
+
+	// State written before dynamic_groups existed kept them in constant
+	for _, c := range getArray(d, "constant") {
+		c := c.(map[string]interface{})
+		if c["constant_type"] == cloudhealth.DynamicGroupType && stringOrNil(c["blk_id"]) != "" {
+			add(c["blk_id"].(string), map[string]interface{}{
+				"ref_id": c["ref_id"],
+				"name":   c["name"],
+				"val":    c["val"],
+			})
+		}
+	}
+
+	return result
+}
+
 func convertRules(groupRefId string, groupName string, groupType string, rules []interface{}) (result []cloudhealth.Rule, err error) {
 	result = make([]cloudhealth.Rule, len(rules))
 
@@ -448,6 +991,26 @@ func convertRules(groupRefId string, groupName string, groupType string, rules [
 	return result, nil
 }
 
+// rulePriorityOrder returns the indexes of the rules, listed group by group,
+// in the order CloudHealth should apply them: by priority, then the rules
+// without one (priority 0) in the order they were listed.
+func rulePriorityOrder(priorities []int) []int {
+	order := make([]int, len(priorities))
+	for idx := range order {
+		order[idx] = idx
+	}
+	sortKey := func(priority int) int {
+		if priority == 0 {
+			return math.MaxInt32
+		}
+		return priority
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return sortKey(priorities[order[i]]) < sortKey(priorities[order[j]])
+	})
+	return order
+}
+
 func convertConditions(conditions []interface{}, combineWith string) (result *cloudhealth.Condition) {
 	if len(conditions) == 0 {
 		return nil
@@ -484,10 +1047,14 @@ func convertConstant(tfConstant map[string]interface{}) (constantType string, co
 	return constantType, constantItem
 }
 
-func addOtherConstants(tfConstants []interface{}, constantsByType map[string]*cloudhealth.Constant) error {
+// addOtherConstants echoes the "other" constants back to CloudHealth. The
+// is_other Static Group is renamed to otherGroupName if set, and added with a
+// new ref_id if CloudHealth hasn't created it yet.
+func addOtherConstants(tfConstants []interface{}, constantsByType map[string]*cloudhealth.Constant, otherGroupName string) error {
 	// Add "other" constants
 	// These are constants that have literally is_other == "true" or dynamic
 	// groups with empty blk_ids
+	hasOtherGroup := false
 	for _, tfConstant := range tfConstants {
 		tfConstant := tfConstant.(map[string]interface{})
 
@@ -500,12 +1067,58 @@ func addOtherConstants(tfConstants []interface{}, constantsByType map[string]*cl
 			if constant == nil {
 				return fmt.Errorf("Unknown constant type %s", constantType)
 			}
+			if constantType == cloudhealth.StaticGroupType && constantItem.IsOther == "true" {
+				hasOtherGroup = true
+				if otherGroupName != "" {
+					constantItem.Name = otherGroupName
+				}
+			}
 			constant.List = append(constant.List, constantItem)
 		}
 	}
+
+	if otherGroupName != "" && !hasOtherGroup {
+		refId, err := nextConstantRefID(tfConstants, constantsByType)
+		if err != nil {
+			return err
+		}
+		constant := constantsByType[cloudhealth.StaticGroupType]
+		constant.List = append(constant.List, cloudhealth.ConstantItem{
+			RefID:   refId,
+			Name:    otherGroupName,
+			IsOther: "true",
+		})
+	}
 	return nil
 }
 
+// nextConstantRefID returns a ref_id used neither by the constants about to
+// be sent nor by the ones CloudHealth knew, so that it doesn't pick up the
+// history of a removed group.
+func nextConstantRefID(tfConstants []interface{}, constantsByType map[string]*cloudhealth.Constant) (string, error) {
+	var refIds []string
+	for _, tfConstant := range tfConstants {
+		refIds = append(refIds, tfConstant.(map[string]interface{})["ref_id"].(string))
+	}
+	for _, constant := range constantsByType {
+		for _, item := range constant.List {
+			refIds = append(refIds, item.RefID)
+		}
+	}
+
+	maxRefId := 0
+	for _, refId := range refIds {
+		refIdInt, err := strconv.Atoi(refId)
+		if err != nil {
+			return "", fmt.Errorf("Constant with non integer ref_id: %s", refId)
+		}
+		if refIdInt >= maxRefId {
+			maxRefId = refIdInt + 1
+		}
+	}
+	return strconv.Itoa(maxRefId), nil
+}
+
 func convertStringArray(maybeStringArray interface{}) []string {
 	if maybeStringArray == nil {
 		return nil
@@ -553,8 +1166,22 @@ func buildPerspective(p *cloudhealth.Perspective, d *schema.ResourceData) error
 
 	constants := buildConstants(p)
 
+	known := getArray(d, "group")
+	keepGroupKeys(groups, known)
+	keepPendingDynamicGroupOverrides(groups, known)
+	groups = assignRulePriorities(groups, known)
 	d.Set("group", groups)
 
+	err = d.Set("other_group", buildOtherGroup(p))
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("merge", buildMerges(p))
+	if err != nil {
+		return err
+	}
+
 	err = d.Set("constant", constants)
 	if err != nil {
 		return err
@@ -562,8 +1189,56 @@ func buildPerspective(p *cloudhealth.Perspective, d *schema.ResourceData) error
 	return nil
 }
 
+// keepGroupKeys copies the keys of the known groups onto the groups read from
+// CloudHealth, which has no notion of them, matching them by ref_id.
+func keepGroupKeys(groups []cloudhealth.Group, known []interface{}) {
+	keyByRef := make(map[string]interface{})
+	for _, g := range known {
+		g := g.(map[string]interface{})
+		if key, _ := g["key"].(string); key != "" {
+			keyByRef[g["ref_id"].(string)] = key
+		}
+	}
+	for _, group := range groups {
+		if key, ok := keyByRef[group["ref_id"].(string)]; ok {
+			group["key"] = key
+		}
+	}
+}
+
+// keepPendingDynamicGroupOverrides copies the dynamic_group entries of the
+// known groups for values CloudHealth hasn't discovered yet onto the groups
+// read from CloudHealth, matching them by ref_id. CloudHealth has nowhere to
+// keep them until the value shows up.
+func keepPendingDynamicGroupOverrides(groups []cloudhealth.Group, known []interface{}) {
+	overridesByRef := make(map[string][]interface{})
+	for _, g := range known {
+		g := g.(map[string]interface{})
+		if overrides, ok := g["dynamic_group"].(*schema.Set); ok {
+			overridesByRef[g["ref_id"].(string)] = overrides.List()
+		}
+	}
+	for _, group := range groups {
+		if group["type"] != "categorize" {
+			continue
+		}
+		discovered := make(map[interface{}]bool)
+		for _, dg := range group["dynamic_groups"].([]interface{}) {
+			discovered[dg.(map[string]interface{})["val"]] = true
+		}
+		overrides := group["dynamic_group"].([]interface{})
+		for _, o := range overridesByRef[group["ref_id"].(string)] {
+			if !discovered[o.(map[string]interface{})["val"]] {
+				overrides = append(overrides, o)
+			}
+		}
+		group["dynamic_group"] = overrides
+	}
+}
+
 func buildGroups(p *cloudhealth.Perspective) (groupByRef map[string]cloudhealth.Group) {
 	groupByRef = make(map[string]cloudhealth.Group)
+	dynamicGroups := buildDynamicGroups(p)
 
 	for _, constant := range p.Schema.Constants {
 		if constant.Type != cloudhealth.StaticGroupType && constant.Type != cloudhealth.DynamicGroupBlockType {
@@ -580,6 +1255,8 @@ func buildGroups(p *cloudhealth.Perspective) (groupByRef map[string]cloudhealth.
 			group["rule"] = make([]map[string]interface{}, 0)
 			if constant.Type == cloudhealth.DynamicGroupBlockType {
 				group["type"] = "categorize"
+				group["dynamic_groups"] = dynamicGroups[constantGroup.RefID]
+				group["dynamic_group"] = buildDynamicGroupOverrides(dynamicGroups[constantGroup.RefID])
 			} else {
 				group["type"] = "filter"
 			}
@@ -589,10 +1266,49 @@ func buildGroups(p *cloudhealth.Perspective) (groupByRef map[string]cloudhealth.
 	return groupByRef
 }
 
+// buildDynamicGroups returns the Dynamic Groups of the perspective keyed by
+// the ref_id of their categorize group
+func buildDynamicGroups(p *cloudhealth.Perspective) map[string][]interface{} {
+	result := make(map[string][]interface{})
+	for _, constant := range p.Schema.Constants {
+		if constant.Type != cloudhealth.DynamicGroupType {
+			continue
+		}
+		for _, constantItem := range constant.List {
+			if constantItem.BlkID == nil || *constantItem.BlkID == "" {
+				// The "other" dynamic group, handled by buildConstants()
+				continue
+			}
+			result[*constantItem.BlkID] = append(result[*constantItem.BlkID], map[string]interface{}{
+				"ref_id": constantItem.RefID,
+				"name":   constantItem.Name,
+				"val":    constantItem.Val,
+			})
+		}
+	}
+	return result
+}
+
+// buildDynamicGroupOverrides returns a dynamic_group entry for every Dynamic
+// Group whose name differs from its value
+func buildDynamicGroupOverrides(dynamicGroups []interface{}) []interface{} {
+	result := make([]interface{}, 0)
+	for _, dg := range dynamicGroups {
+		dg := dg.(map[string]interface{})
+		if dg["name"] != dg["val"] {
+			result = append(result, map[string]interface{}{
+				"val":  dg["val"],
+				"name": dg["name"],
+			})
+		}
+	}
+	return result
+}
+
 func populateRules(p *cloudhealth.Perspective, groupByRef map[string]cloudhealth.Group) (groups []cloudhealth.Group, err error) {
 	groupByRefSeen := make(map[string]bool)
 	groups = make([]cloudhealth.Group, 0)
-	for _, jsonRule := range p.Schema.Rules {
+	for ruleIdx, jsonRule := range p.Schema.Rules {
 		groupRef := jsonRule.To
 		if groupRef == "" {
 			groupRef = jsonRule.RefID
@@ -608,8 +1324,9 @@ func populateRules(p *cloudhealth.Perspective, groupByRef map[string]cloudhealth
 		}
 
 		// Order the groups by order that the rules are seen.  CHT technically
-		// allows the groups for rules to be interleaved, but this is horribly
-		// confusing and not in the UI
+		// allows the groups for rules to be interleaved, which the UI doesn't
+		// show; the position of each rule is kept as its priority so that
+		// assignRulePriorities can reproduce it
 		if groupByRefSeen[groupRef] == false {
 			groups = append(groups, group)
 			groupByRefSeen[groupRef] = true
@@ -621,6 +1338,7 @@ func populateRules(p *cloudhealth.Perspective, groupByRef map[string]cloudhealth
 			return nil, fmt.Errorf("Unknown rule type %s; expected %s", jsonRule.Type, group["type"])
 		}
 		rule["asset"] = jsonRule.Asset
+		rule["priority"] = ruleIdx + 1
 		if jsonRule.TagField != nil {
 			rule["tag_field"] = jsonRule.TagField
 		}
@@ -640,6 +1358,87 @@ func populateRules(p *cloudhealth.Perspective, groupByRef map[string]cloudhealth
 	return groups, nil
 }
 
+// assignRulePriorities settles the priorities of the rules read from
+// CloudHealth, which populateRules set to their position. The known groups'
+// order and priorities are kept when they give the same order, otherwise
+// priorities are only kept when the rules of different groups are
+// interleaved.
+func assignRulePriorities(groups []cloudhealth.Group, known []interface{}) []cloudhealth.Group {
+	knownByRef := make(map[string]map[string]interface{})
+	knownOrder := make(map[string]int)
+	for idx, g := range known {
+		g := g.(map[string]interface{})
+		knownByRef[g["ref_id"].(string)] = g
+		knownOrder[g["ref_id"].(string)] = idx
+	}
+
+	// The known groups in their order, followed by any new ones
+	ordered := make([]cloudhealth.Group, len(groups))
+	copy(ordered, groups)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		oi, iKnown := knownOrder[ordered[i]["ref_id"].(string)]
+		oj, jKnown := knownOrder[ordered[j]["ref_id"].(string)]
+		if iKnown && jKnown {
+			return oi < oj
+		}
+		return iKnown && !jKnown
+	})
+
+	knownPriority := func(group cloudhealth.Group, ruleIdx int) int {
+		knownGroup, ok := knownByRef[group["ref_id"].(string)]
+		if !ok {
+			return 0
+		}
+		knownRules, _ := knownGroup["rule"].([]interface{})
+		if ruleIdx >= len(knownRules) {
+			return 0
+		}
+		priority, _ := knownRules[ruleIdx].(map[string]interface{})["priority"].(int)
+		return priority
+	}
+	noPriority := func(group cloudhealth.Group, ruleIdx int) int {
+		return 0
+	}
+
+	switch {
+	case rulesInPositionOrder(ordered, knownPriority):
+		setRulePriorities(ordered, knownPriority)
+		return ordered
+	case rulesInPositionOrder(groups, noPriority):
+		setRulePriorities(groups, noPriority)
+		return groups
+	default:
+		return ordered
+	}
+}
+
+// rulesInPositionOrder reports whether the given priorities order the rules of
+// the groups the way CloudHealth has them, i.e. by the position populateRules
+// set as their priority.
+func rulesInPositionOrder(groups []cloudhealth.Group, priorityOf func(cloudhealth.Group, int) int) bool {
+	var positions, priorities []int
+	for _, group := range groups {
+		for ruleIdx, rule := range group["rule"].([]map[string]interface{}) {
+			positions = append(positions, rule["priority"].(int))
+			priorities = append(priorities, priorityOf(group, ruleIdx))
+		}
+	}
+	for idx, ruleIdx := range rulePriorityOrder(priorities) {
+		if positions[ruleIdx] != idx+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func setRulePriorities(groups []cloudhealth.Group, priorityOf func(cloudhealth.Group, int) int) {
+	for _, group := range groups {
+		for ruleIdx, rule := range group["rule"].([]map[string]interface{}) {
+			rule["priority"] = priorityOf(group, ruleIdx)
+		}
+	}
+}
+
 func buildCondition(srcClauses []cloudhealth.Clause) (clauses []map[string]interface{}) {
 	clauses = make([]map[string]interface{}, len(srcClauses))
 
@@ -659,10 +1458,44 @@ func buildCondition(srcClauses []cloudhealth.Clause) (clauses []map[string]inter
 	return clauses
 }
 
+func buildMerges(p *cloudhealth.Perspective) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(p.Schema.Merges))
+	for idx, srcMerge := range p.Schema.Merges {
+		result[idx] = map[string]interface{}{
+			"type": srcMerge.Type,
+			"to":   srcMerge.To,
+			"from": srcMerge.From,
+		}
+	}
+	return result
+}
+
+// buildOtherGroup returns the other_group block of the is_other Static Group,
+// if the perspective has one.
+func buildOtherGroup(p *cloudhealth.Perspective) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, 1)
+	for _, constant := range p.Schema.Constants {
+		if constant.Type != cloudhealth.StaticGroupType {
+			continue
+		}
+		for _, item := range constant.List {
+			if item.IsOther == "true" {
+				result = append(result, map[string]interface{}{"name": item.Name})
+				return result
+			}
+		}
+	}
+	return result
+}
+
 func buildConstants(p *cloudhealth.Perspective) []cloudhealth.Group {
 	result := make([]cloudhealth.Group, 0)
 	for _, srcConstant := range p.Schema.Constants {
 		for _, srcConstantGroup := range srcConstant.List {
+			if srcConstant.Type == cloudhealth.DynamicGroupType && srcConstantGroup.BlkID != nil && *srcConstantGroup.BlkID != "" {
+				// Belongs to a categorize group, see buildDynamicGroups()
+				continue
+			}
 			constant := cloudhealth.Group{
 				"constant_type": srcConstant.Type,
 				"ref_id":        srcConstantGroup.RefID,
@@ -0,0 +1,403 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+// resourceCloudHealthPerspectiveJSON manages a perspective from the JSON
+// document CloudHealth exports, {"schema": {...}}, for perspectives too large
+// to write out as group blocks.
+func resourceCloudHealthPerspectiveJSON() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCloudHealthPerspectiveJSONCreate,
+		Read:   resourceCloudHealthPerspectiveJSONRead,
+		Update: resourceCloudHealthPerspectiveJSONUpdate,
+		Delete: resourceCloudHealthPerspectiveDelete,
+		Importer: &schema.ResourceImporter{
+			State: importStateWithClientAPIID,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"client_api_id": clientAPIIDSchema(),
+			"schema_json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validatePerspectiveJSON,
+				DiffSuppressFunc: suppressEquivalentPerspectiveJSON,
+			},
+			"on_destroy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      perspectiveOnDestroyHardDelete,
+				ValidateFunc: validateStringInSlice([]string{perspectiveOnDestroyHardDelete, perspectiveOnDestroyArchive}),
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCloudHealthPerspectiveJSONCreate(d *schema.ResourceData, m interface{}) error {
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutCreate)
+	defer cancel()
+
+	perspective, err := expandPerspectiveJSON(d.Get("schema_json").(string))
+	if err != nil {
+		return err
+	}
+
+	id, err := client.CreatePerspectiveWithContext(ctx, perspective)
+	if err != nil {
+		return fmt.Errorf("Could not create perspective: %v", err)
+	}
+
+	d.SetId(id)
+	return resourceCloudHealthPerspectiveJSONRead(d, m)
+}
+
+func resourceCloudHealthPerspectiveJSONRead(d *schema.ResourceData, m interface{}) error {
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutRead)
+	defer cancel()
+
+	id := d.Id()
+	perspective, err := client.GetPerspectiveWithContext(ctx, id)
+	if errors.Is(err, cloudhealth.ErrPerspectiveNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error when reading perspective %s: %v", id, err)
+	}
+
+	// Imported perspectives have no on_destroy yet
+	if _, ok := d.GetOk("on_destroy"); !ok {
+		d.Set("on_destroy", perspectiveOnDestroyHardDelete)
+	}
+	d.Set("name", perspective.Schema.Name)
+
+	remote, err := json.Marshal(perspective)
+	if err != nil {
+		return err
+	}
+	// Keep the document as written unless CloudHealth's copy differs in substance
+	if !perspectiveJSONEquivalent(string(remote), d.Get("schema_json").(string)) {
+		d.Set("schema_json", string(remote))
+	}
+	return nil
+}
+
+func resourceCloudHealthPerspectiveJSONUpdate(d *schema.ResourceData, m interface{}) error {
+	client := clientFor(d, m)
+	ctx, cancel := operationContext(d, m, schema.TimeoutUpdate)
+	defer cancel()
+
+	if d.HasChange("schema_json") {
+		perspective, err := expandPerspectiveJSON(d.Get("schema_json").(string))
+		if err != nil {
+			return err
+		}
+
+		// The document has no Dynamic Groups CloudHealth discovered after it
+		// was written, keep them rather than deleting them with the update
+		remote, err := client.GetPerspectiveWithContext(ctx, d.Id())
+		if err != nil {
+			return fmt.Errorf("Error when reading perspective %s: %v", d.Id(), err)
+		}
+		keepDiscoveredDynamicGroups(&perspective.Schema, remote.Schema)
+
+		_, err = client.UpdatePerspectiveWithContext(ctx, d.Id(), perspective)
+		if err != nil {
+			return fmt.Errorf("Could not update perspective %s: %v", d.Id(), err)
+		}
+	}
+
+	return resourceCloudHealthPerspectiveJSONRead(d, m)
+}
+
+// expandPerspectiveJSON parses a perspective document, rejecting keys the
+// provider doesn't know rather than silently leaving them out of what it
+// sends to CloudHealth.
+func expandPerspectiveJSON(document string) (*cloudhealth.Perspective, error) {
+	perspective := new(cloudhealth.Perspective)
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(perspective); err != nil {
+		return nil, fmt.Errorf("Could not parse perspective JSON: %v", err)
+	}
+	if perspective.Schema.Name == "" {
+		return nil, fmt.Errorf("Perspective JSON has no schema.name")
+	}
+	return perspective, nil
+}
+
+func validatePerspectiveJSON(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := expandPerspectiveJSON(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+	}
+	return
+}
+
+// suppressEquivalentPerspectiveJSON hides differences CloudHealth doesn't
+// care about or introduces itself: key order, the numbering of ref_ids,
+// missing versus empty lists and, when the configuration leaves them out, the
+// "Other" group CloudHealth adds and the Dynamic Groups it discovers.
+func suppressEquivalentPerspectiveJSON(k, old, new string, d *schema.ResourceData) bool {
+	return perspectiveJSONEquivalent(old, new)
+}
+
+// perspectiveJSONEquivalent reports whether the remote document describes the
+// same perspective as the configured one.
+func perspectiveJSONEquivalent(remote, configured string) bool {
+	remotePerspective, err := expandPerspectiveJSON(remote)
+	if err != nil {
+		return false
+	}
+	configuredPerspective, err := expandPerspectiveJSON(configured)
+	if err != nil {
+		return false
+	}
+
+	if !hasOtherConstant(configuredPerspective.Schema) {
+		removeOtherConstants(&remotePerspective.Schema)
+	}
+	removeDiscoveredDynamicGroups(&remotePerspective.Schema, configuredPerspective.Schema)
+	remoteJSON, err := json.Marshal(canonicalPerspectiveSchema(remotePerspective.Schema))
+	if err != nil {
+		return false
+	}
+	configuredJSON, err := json.Marshal(canonicalPerspectiveSchema(configuredPerspective.Schema))
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(remoteJSON, configuredJSON)
+}
+
+func hasOtherConstant(s cloudhealth.Schema) bool {
+	for _, constant := range s.Constants {
+		for _, item := range constant.List {
+			if item.IsOther == "true" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func removeOtherConstants(s *cloudhealth.Schema) {
+	for i, constant := range s.Constants {
+		list := make([]cloudhealth.ConstantItem, 0, len(constant.List))
+		for _, item := range constant.List {
+			if item.IsOther != "true" {
+				list = append(list, item)
+			}
+		}
+		s.Constants[i].List = list
+	}
+}
+
+// dynamicGroupKey identifies a Dynamic Group by the name of its categorize
+// block and its value, which unlike ref_ids survive renumbering.
+type dynamicGroupKey struct {
+	block string
+	val   string
+}
+
+// dynamicGroupBlockNames maps the ref_ids of the Dynamic Group Blocks of a
+// schema to their names.
+func dynamicGroupBlockNames(s cloudhealth.Schema) map[string]string {
+	names := make(map[string]string)
+	for _, constant := range s.Constants {
+		if constant.Type != cloudhealth.DynamicGroupBlockType {
+			continue
+		}
+		for _, item := range constant.List {
+			names[item.RefID] = item.Name
+		}
+	}
+	return names
+}
+
+// discoveredDynamicGroupItems returns the Dynamic Groups of remote that
+// configured doesn't list, i.e. those CloudHealth discovered itself.
+func discoveredDynamicGroupItems(remote, configured cloudhealth.Schema) []cloudhealth.ConstantItem {
+	configuredBlocks := dynamicGroupBlockNames(configured)
+	declared := make(map[dynamicGroupKey]bool)
+	for _, constant := range configured.Constants {
+		if constant.Type != cloudhealth.DynamicGroupType {
+			continue
+		}
+		for _, item := range constant.List {
+			if item.BlkID != nil {
+				declared[dynamicGroupKey{configuredBlocks[*item.BlkID], item.Val}] = true
+			}
+		}
+	}
+
+	remoteBlocks := dynamicGroupBlockNames(remote)
+	var discovered []cloudhealth.ConstantItem
+	for _, constant := range remote.Constants {
+		if constant.Type != cloudhealth.DynamicGroupType {
+			continue
+		}
+		for _, item := range constant.List {
+			if item.BlkID != nil && !declared[dynamicGroupKey{remoteBlocks[*item.BlkID], item.Val}] {
+				discovered = append(discovered, item)
+			}
+		}
+	}
+	return discovered
+}
+
+func removeDiscoveredDynamicGroups(remote *cloudhealth.Schema, configured cloudhealth.Schema) {
+	discovered := make(map[string]bool)
+	for _, item := range discoveredDynamicGroupItems(*remote, configured) {
+		discovered[item.RefID] = true
+	}
+	for i, constant := range remote.Constants {
+		if constant.Type != cloudhealth.DynamicGroupType {
+			continue
+		}
+		list := make([]cloudhealth.ConstantItem, 0, len(constant.List))
+		for _, item := range constant.List {
+			if !discovered[item.RefID] {
+				list = append(list, item)
+			}
+		}
+		remote.Constants[i].List = list
+	}
+}
+
+// keepDiscoveredDynamicGroups adds the Dynamic Groups CloudHealth discovered
+// to a configured schema about to be sent, pointing them at the configured
+// block of the same name. Their ref_ids are kept unless the configured schema
+// uses them already. Groups of blocks the configured schema no longer has are
+// dropped with their block.
+func keepDiscoveredDynamicGroups(configured *cloudhealth.Schema, remote cloudhealth.Schema) {
+	discovered := discoveredDynamicGroupItems(remote, *configured)
+	if len(discovered) == 0 {
+		return
+	}
+
+	blockRefIDs := make(map[string]string)
+	for refID, name := range dynamicGroupBlockNames(*configured) {
+		blockRefIDs[name] = refID
+	}
+	configuredRefIDs := make(map[string]bool)
+	nextRefID := 0
+	bump := func(refID string) {
+		if n, err := strconv.Atoi(refID); err == nil && n >= nextRefID {
+			nextRefID = n + 1
+		}
+	}
+	for _, rule := range configured.Rules {
+		configuredRefIDs[rule.To] = true
+		configuredRefIDs[rule.RefID] = true
+		bump(rule.To)
+		bump(rule.RefID)
+	}
+	for _, constant := range configured.Constants {
+		for _, item := range constant.List {
+			configuredRefIDs[item.RefID] = true
+			bump(item.RefID)
+		}
+	}
+	for _, item := range discovered {
+		bump(item.RefID)
+	}
+
+	remoteBlocks := dynamicGroupBlockNames(remote)
+	var items []cloudhealth.ConstantItem
+	for _, item := range discovered {
+		blkID, ok := blockRefIDs[remoteBlocks[*item.BlkID]]
+		if !ok {
+			continue
+		}
+		item.BlkID = &blkID
+		if configuredRefIDs[item.RefID] {
+			item.RefID = strconv.Itoa(nextRefID)
+			nextRefID++
+		}
+		items = append(items, item)
+	}
+
+	for i, constant := range configured.Constants {
+		if constant.Type == cloudhealth.DynamicGroupType {
+			configured.Constants[i].List = append(constant.List, items...)
+			return
+		}
+	}
+	if len(items) > 0 {
+		configured.Constants = append(configured.Constants, cloudhealth.Constant{
+			Type: cloudhealth.DynamicGroupType,
+			List: items,
+		})
+	}
+}
+
+// canonicalPerspectiveSchema renumbers the ref_ids of a schema in order of
+// first appearance and turns missing lists into empty ones, so that two
+// documents for the same perspective marshal to the same JSON.
+func canonicalPerspectiveSchema(s cloudhealth.Schema) cloudhealth.Schema {
+	refIDs := make(map[string]string)
+	renumber := func(refID string) string {
+		if refID == "" {
+			return ""
+		}
+		if _, ok := refIDs[refID]; !ok {
+			refIDs[refID] = strconv.Itoa(len(refIDs))
+		}
+		return refIDs[refID]
+	}
+
+	result := cloudhealth.Schema{
+		Name:             s.Name,
+		IncludeInReports: s.IncludeInReports,
+		Rules:            make([]cloudhealth.Rule, 0, len(s.Rules)),
+		Constants:        make([]cloudhealth.Constant, 0, len(s.Constants)),
+		Merges:           make([]cloudhealth.Merge, 0, len(s.Merges)),
+	}
+	for _, rule := range s.Rules {
+		rule.To = renumber(rule.To)
+		rule.RefID = renumber(rule.RefID)
+		result.Rules = append(result.Rules, rule)
+	}
+	for _, constant := range s.Constants {
+		if len(constant.List) == 0 {
+			continue
+		}
+		list := make([]cloudhealth.ConstantItem, len(constant.List))
+		for i, item := range constant.List {
+			item.RefID = renumber(item.RefID)
+			if item.BlkID != nil {
+				blkID := renumber(*item.BlkID)
+				item.BlkID = &blkID
+			}
+			list[i] = item
+		}
+		result.Constants = append(result.Constants, cloudhealth.Constant{Type: constant.Type, List: list})
+	}
+	for _, merge := range s.Merges {
+		from := make([]string, len(merge.From))
+		for i, refID := range merge.From {
+			from[i] = renumber(refID)
+		}
+		result.Merges = append(result.Merges, cloudhealth.Merge{
+			Type: merge.Type,
+			To:   renumber(merge.To),
+			From: from,
+		})
+	}
+	return result
+}
@@ -0,0 +1,243 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+	"github.com/nextgenhealthcare/terraform-provider-cloudhealth/internal/fakeapi"
+)
+
+func TestUnitCloudHealthPerspectiveJSON_basic(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveJSON(testUnitPerspectiveJSONDocument),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthPerspectiveExists("cloudhealth_perspective_json.acc_test_perspective"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective_json.acc_test_perspective", "name", "From JSON"),
+				),
+			},
+			{
+				// Same perspective with other key order, ref_ids and empty merges
+				Config:   testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveJSON(testUnitPerspectiveJSONEquivalentDocument),
+				PlanOnly: true,
+			},
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveJSON(`{"schema": {"name": "Renamed From JSON", "include_in_reports": "false"}}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudhealth_perspective_json.acc_test_perspective", "name", "Renamed From JSON"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspectiveJSON_discoveredDynamicGroups(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveJSON(testUnitPerspectiveJSONDocument),
+				Check: resource.ComposeTestCheckFunc(
+					testUnitDiscoverPerspectiveJSONDynamicGroup(api, "cloudhealth_perspective_json.acc_test_perspective", "11", "alice"),
+				),
+			},
+			{
+				Config:   testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveJSON(testUnitPerspectiveJSONDocument),
+				PlanOnly: true,
+			},
+			{
+				// Updating the document keeps the Dynamic Group CloudHealth discovered
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveJSON(strings.Replace(testUnitPerspectiveJSONDocument, "Team A", "Team B", 1)),
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckPerspectiveJSONDynamicGroup("cloudhealth_perspective_json.acc_test_perspective", "Owner", "alice"),
+				),
+			},
+			{
+				Config:   testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveJSON(strings.Replace(testUnitPerspectiveJSONDocument, "Team A", "Team B", 1)),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspectiveJSON_unknownKeys(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveJSON(`{"schema": {"name": "a", "include_in_reports": "false", "rulez": []}}`),
+				ExpectError: regexp.MustCompile(`unknown field "rulez"`),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspectiveJSON_equivalent(t *testing.T) {
+	cases := []struct {
+		remote     string
+		configured string
+		expected   bool
+	}{
+		{testUnitPerspectiveJSONDocument, testUnitPerspectiveJSONEquivalentDocument, true},
+		{
+			`{"schema": {"name": "a", "include_in_reports": "true", "constants": [{"type": "Static Group", "list": [{"ref_id": "1", "name": "Other", "is_other": "true"}]}]}}`,
+			`{"schema": {"name": "a", "include_in_reports": "true"}}`,
+			true,
+		},
+		{
+			`{"schema": {"name": "a", "include_in_reports": "true", "constants": [{"type": "Static Group", "list": [{"ref_id": "1", "name": "Other", "is_other": "true"}]}]}}`,
+			`{"schema": {"name": "a", "include_in_reports": "true", "constants": [{"type": "Static Group", "list": [{"ref_id": "1", "name": "Unallocated", "is_other": "true"}]}]}}`,
+			false,
+		},
+		{
+			`{"schema": {"name": "a", "include_in_reports": "true", "constants": [{"type": "Dynamic Group Block", "list": [{"ref_id": "1", "name": "Owner"}]}, {"type": "Dynamic Group", "list": [{"ref_id": "2", "blk_id": "1", "name": "alice", "val": "alice"}]}]}}`,
+			`{"schema": {"name": "a", "include_in_reports": "true", "constants": [{"type": "Dynamic Group Block", "list": [{"ref_id": "1", "name": "Owner"}]}]}}`,
+			true,
+		},
+		{
+			`{"schema": {"name": "a", "include_in_reports": "true", "constants": [{"type": "Dynamic Group Block", "list": [{"ref_id": "1", "name": "Owner"}]}, {"type": "Dynamic Group", "list": [{"ref_id": "2", "blk_id": "1", "name": "alice", "val": "alice"}]}]}}`,
+			`{"schema": {"name": "a", "include_in_reports": "true", "constants": [{"type": "Dynamic Group Block", "list": [{"ref_id": "1", "name": "Owner"}]}, {"type": "Dynamic Group", "list": [{"ref_id": "2", "blk_id": "1", "name": "Alice", "val": "alice"}]}]}}`,
+			false,
+		},
+		{
+			`{"schema": {"name": "a", "include_in_reports": "true", "merges": null}}`,
+			`{"schema": {"name": "a", "include_in_reports": "true", "merges": []}}`,
+			true,
+		},
+		{
+			`{"schema": {"name": "a", "include_in_reports": "true", "rules": [{"type": "filter", "asset": "AwsAsset", "to": "1"}, {"type": "filter", "asset": "AwsAsset", "to": "2"}]}}`,
+			`{"schema": {"name": "a", "include_in_reports": "true", "rules": [{"type": "filter", "asset": "AwsAsset", "to": "2"}, {"type": "filter", "asset": "AwsAsset", "to": "2"}]}}`,
+			false,
+		},
+	}
+
+	for i, c := range cases {
+		if actual := perspectiveJSONEquivalent(c.remote, c.configured); actual != c.expected {
+			t.Errorf("Case %d: expected %t, got %t", i, c.expected, actual)
+		}
+	}
+}
+
+func testUnitDiscoverPerspectiveJSONDynamicGroup(api *fakeapi.Server, n string, blkID string, val string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		api.DiscoverDynamicGroup(r.Primary.ID, blkID, val)
+		return nil
+	}
+}
+
+// testUnitCheckPerspectiveJSONDynamicGroup checks that CloudHealth has a
+// Dynamic Group for val in the categorize block named block.
+func testUnitCheckPerspectiveJSONDynamicGroup(n string, block string, val string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		perspective, err := testAccClientFor(r).GetPerspective(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+		blocks := dynamicGroupBlockNames(perspective.Schema)
+		for _, constant := range perspective.Schema.Constants {
+			if constant.Type != cloudhealth.DynamicGroupType {
+				continue
+			}
+			for _, item := range constant.List {
+				if item.Val == val && item.BlkID != nil && blocks[*item.BlkID] == block {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("No Dynamic Group %q in block %q of perspective %s", val, block, r.Primary.ID)
+	}
+}
+
+func testUnitCloudHealthPerspectiveJSON(document string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_perspective_json" "acc_test_perspective" {
+  schema_json = <<EOF
+%s
+EOF
+}
+`, document)
+}
+
+const testUnitPerspectiveJSONDocument = `{
+  "schema": {
+    "name": "From JSON",
+    "include_in_reports": "false",
+    "rules": [
+      {
+        "type": "filter",
+        "asset": "AwsAsset",
+        "to": "10",
+        "condition": {
+          "clauses": [{"tag_field": ["team"], "op": "=", "val": "a"}]
+        }
+      },
+      {
+        "type": "categorize",
+        "asset": "AwsAsset",
+        "ref_id": "11",
+        "name": "Owner",
+        "tag_field": ["owner"]
+      }
+    ],
+    "constants": [
+      {"type": "Static Group", "list": [{"ref_id": "10", "name": "Team A"}]},
+      {"type": "Dynamic Group Block", "list": [{"ref_id": "11", "name": "Owner"}]}
+    ],
+    "merges": null
+  }
+}`
+
+const testUnitPerspectiveJSONEquivalentDocument = `{
+  "schema": {
+    "merges": [],
+    "constants": [
+      {"list": [{"name": "Team A", "ref_id": "1"}], "type": "Static Group"},
+      {"list": [{"name": "Owner", "ref_id": "2"}], "type": "Dynamic Group Block"}
+    ],
+    "rules": [
+      {
+        "to": "1",
+        "asset": "AwsAsset",
+        "type": "filter",
+        "condition": {
+          "clauses": [{"val": "a", "op": "=", "tag_field": ["team"]}]
+        }
+      },
+      {
+        "tag_field": ["owner"],
+        "name": "Owner",
+        "ref_id": "2",
+        "asset": "AwsAsset",
+        "type": "categorize"
+      }
+    ],
+    "include_in_reports": "false",
+    "name": "From JSON"
+  }
+}`
@@ -1,13 +1,18 @@
 package cloudhealth
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+	"github.com/nextgenhealthcare/terraform-provider-cloudhealth/internal/fakeapi"
 )
 
 func TestAccCloudHealthPerspective_basic(t *testing.T) {
@@ -27,12 +32,559 @@ func TestAccCloudHealthPerspective_basic(t *testing.T) {
 	})
 }
 
+func TestAccCloudHealthPerspective_archive(t *testing.T) {
+	perspectiveName := fmt.Sprintf("perspective-%s", acctest.RandString(10))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveArchived,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudHealthPerspectiveWithArchive(perspectiveName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthPerspectiveExists("cloudhealth_perspective.acc_test_perspective"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "on_destroy", "archive"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_basic(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	perspectiveName := fmt.Sprintf("perspective-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testAccCloudHealthPerspectiveWithDefaults(perspectiveName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthPerspectiveExists("cloudhealth_perspective.acc_test_perspective"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "group.0.ref_id", "0"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "constant.1.name", "Other"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "constant.1.is_other", "true"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "other_group.0.name", "Other"),
+				),
+			},
+			{
+				Config:            testUnitProviderConfig(api) + testAccCloudHealthPerspectiveWithDefaults(perspectiveName),
+				ResourceName:      "cloudhealth_perspective.acc_test_perspective",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_archive(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	perspectiveName := fmt.Sprintf("perspective-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveArchived,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testAccCloudHealthPerspectiveWithArchive(perspectiveName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthPerspectiveExists("cloudhealth_perspective.acc_test_perspective"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_adoptArchived(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	perspectiveName := fmt.Sprintf("perspective-%s", acctest.RandString(10))
+	archivedID := api.AddPerspective(cloudhealth.Schema{
+		Name:             perspectiveName,
+		IncludeInReports: "false",
+	}, false)
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				// Adoption is opt-in
+				Config:      testUnitProviderConfig(api) + testAccCloudHealthPerspectiveWithDefaults(perspectiveName),
+				ExpectError: regexp.MustCompile(`Name has already been taken`),
+			},
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithAdoptArchived(perspectiveName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "id", archivedID),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_adoptArchivedAmbiguous(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	perspectiveName := fmt.Sprintf("perspective-%s", acctest.RandString(10))
+	for i := 0; i < 2; i++ {
+		api.AddPerspective(cloudhealth.Schema{
+			Name:             perspectiveName,
+			IncludeInReports: "false",
+		}, false)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithAdoptArchived(perspectiveName),
+				ExpectError: regexp.MustCompile(`2 archived perspectives found with name`),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_clientAPIID(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	perspectiveName := fmt.Sprintf("perspective-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithClientAPIID(perspectiveName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudHealthPerspectiveExists("cloudhealth_perspective.acc_test_perspective"),
+					testUnitCheckTenant(api, "cloudhealth_perspective.acc_test_perspective", "42"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "name", perspectiveName),
+				),
+			},
+			{
+				Config:              testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithClientAPIID(perspectiveName),
+				ResourceName:        "cloudhealth_perspective.acc_test_perspective",
+				ImportState:         true,
+				ImportStateIdPrefix: "42/",
+				ImportStateVerify:   true,
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_validation(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithRule("filter", testUnitPerspectiveUnknownOpRule),
+				ExpectError: regexp.MustCompile(`"group\[1\]\.rule\[0\]\.condition\[0\]\.op" must be one of`),
+			},
+			{
+				Config:      testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithRule("filter", `combine_with = "XOR"`),
+				ExpectError: regexp.MustCompile(`"group\[1\]\.rule\[0\]\.combine_with" must be one of`),
+			},
+			{
+				Config:      testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithRule("static", ""),
+				ExpectError: regexp.MustCompile(`"group\[1\]\.type" must be one of`),
+			},
+			{
+				Config:      testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithRule("categorize", ""),
+				ExpectError: regexp.MustCompile(`group\[1\]\.rule\[0\]: a categorize rule needs a field or tag_field`),
+			},
+			{
+				Config:      testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithRule("filter", testUnitPerspectiveFieldAndTagFieldRule),
+				ExpectError: regexp.MustCompile(`group\[1\]\.rule\[0\]\.condition\[0\]: only one of field and tag_field can be set`),
+			},
+		},
+	})
+}
+
+// TestUnitCloudHealthPerspective_validate checks which mistakes terraform
+// validate reports on its own, i.e. without the plan-time CustomizeDiff.
+func TestUnitCloudHealthPerspective_validate(t *testing.T) {
+	perspective := func(groupType string, rule map[string]interface{}) map[string]interface{} {
+		rule["asset"] = "AwsAsset"
+		return map[string]interface{}{
+			"name":               "validate",
+			"include_in_reports": false,
+			"group": []interface{}{
+				map[string]interface{}{
+					"name": "Team A",
+					"type": groupType,
+					"rule": []interface{}{rule},
+				},
+			},
+		}
+	}
+	condition := func(c map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"condition": []interface{}{c}}
+	}
+
+	cases := []struct {
+		raw      map[string]interface{}
+		expected string
+	}{
+		{perspective("filter", condition(map[string]interface{}{"tag_field": []interface{}{"team"}, "op": "Like", "val": "a"})), `group\[0\]\.rule\[0\]\.condition\[0\]\.op" must be one of`},
+		{perspective("filter", map[string]interface{}{"combine_with": "XOR"}), `group\[0\]\.rule\[0\]\.combine_with" must be one of`},
+		{perspective("static", map[string]interface{}{}), `group\[0\]\.type" must be one of`},
+		{perspective("filter", map[string]interface{}{"priority": 0}), `group\[0\]\.rule\[0\]\.priority`},
+		// Checks that involve several fields are left to plan time
+		{perspective("categorize", map[string]interface{}{}), ""},
+		{perspective("filter", condition(map[string]interface{}{"tag_field": []interface{}{"team"}, "field": []interface{}{"Name"}, "val": "a"})), ""},
+	}
+
+	for i, c := range cases {
+		raw, err := config.NewRawConfig(c.raw)
+		if err != nil {
+			t.Fatalf("Case %d: %v", i, err)
+		}
+		_, errs := resourceCloudHealthPerspective().Validate(terraform.NewResourceConfig(raw))
+		if c.expected == "" {
+			if len(errs) > 0 {
+				t.Errorf("Case %d: expected no errors, got %v", i, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || !regexp.MustCompile(c.expected).MatchString(errs[0].Error()) {
+			t.Errorf("Case %d: expected an error matching %q, got %v", i, c.expected, errs)
+		}
+	}
+}
+
+func TestUnitCloudHealthPerspective_groupKeys(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithKeyedGroups("team-a", "Team A", "team-b", "Team B"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "group.0.ref_id", "0"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "group.1.ref_id", "1"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "group_ref_ids.team-b", "1"),
+				),
+			},
+			{
+				// Reordered and renamed at once
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithKeyedGroups("team-b", "Team B Renamed", "team-a", "Team A"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "group.0.name", "Team B Renamed"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "group.0.key", "team-b"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "group.0.ref_id", "1"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "group.1.ref_id", "0"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "group_ref_ids.team-b", "1"),
+				),
+			},
+			{
+				Config:      testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithKeyedGroups("team-a", "Team A", "team-a", "Team B"),
+				ExpectError: regexp.MustCompile(`group\[1\]\.key: "team-a" already identifies group\[0\]`),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_otherGroup(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	perspectiveName := fmt.Sprintf("perspective-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithOtherGroup(perspectiveName, "Unallocated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "other_group.0.name", "Unallocated"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "constant.1.name", "Unallocated"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "constant.1.is_other", "true"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "constant.1.ref_id", "1"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithOtherGroup(perspectiveName, "Unassigned"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "other_group.0.name", "Unassigned"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "constant.1.name", "Unassigned"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "constant.1.ref_id", "1"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "constant.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_rulePriority(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	perspectiveName := fmt.Sprintf("perspective-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithPriorities(perspectiveName, 10, 30, 20),
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckCloudHealthPerspectiveRuleValues("cloudhealth_perspective.acc_test_perspective", "a-specific", "catch-all", "a-fallback"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "group.0.rule.1.priority", "30"),
+				),
+			},
+			{
+				// Imported rules are numbered by their position
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithPriorities(perspectiveName, 1, 3, 2),
+			},
+			{
+				Config:            testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithPriorities(perspectiveName, 1, 3, 2),
+				ResourceName:      "cloudhealth_perspective.acc_test_perspective",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithPriorities(perspectiveName, 3, 1, 2),
+				ExpectError: regexp.MustCompile(`group\[0\]\.rule\[1\]: the rules of a group must be listed in priority order`),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_merges(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	perspectiveName := fmt.Sprintf("perspective-%s", acctest.RandString(10))
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithMerge(perspectiveName, testUnitPerspectiveMerge),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "merge.#", "1"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "merge.0.to", "0"),
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "merge.0.from.0", "1"),
+				),
+			},
+			{
+				Config:            testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithMerge(perspectiveName, testUnitPerspectiveMerge),
+				ResourceName:      "cloudhealth_perspective.acc_test_perspective",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Leaving out every merge block deletes the merges
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithMerge(perspectiveName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cloudhealth_perspective.acc_test_perspective", "merge.#", "0"),
+					testUnitCheckCloudHealthPerspectiveMerges("cloudhealth_perspective.acc_test_perspective", 0),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_dynamicGroups(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	const n = "cloudhealth_perspective.acc_test_perspective"
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithDynamicGroups(false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.#", "0"),
+					testUnitDiscoverDynamicGroups(api, n, 0, "alice", "bob"),
+				),
+			},
+			{
+				// Discovered dynamic groups show up once refreshed
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithDynamicGroups(false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.#", "2"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.0.val", "alice"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.0.name", "alice"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.0.ref_id", "3"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.1.val", "bob"),
+				),
+			},
+			{
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithDynamicGroups(false, "Alice"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.#", "2"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.0.name", "Alice"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.1.name", "bob"),
+				),
+			},
+			{
+				// Renaming an override keeps the dynamic group
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithDynamicGroups(false, "Alice Smith"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.0.ref_id", "3"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.#", "2"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.0.name", "Alice Smith"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.0.val", "alice"),
+				),
+			},
+			{
+				// Dynamic groups follow their group when groups are reordered
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithDynamicGroups(true, "Alice Smith"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(n, "group.0.name", "Team A"),
+					resource.TestCheckResourceAttr(n, "group.1.name", "Owner"),
+					resource.TestCheckResourceAttr(n, "group.1.ref_id", "0"),
+					resource.TestCheckResourceAttr(n, "group.1.dynamic_groups.#", "2"),
+					resource.TestCheckResourceAttr(n, "group.1.dynamic_groups.0.ref_id", "3"),
+					resource.TestCheckResourceAttr(n, "group.1.dynamic_groups.0.name", "Alice Smith"),
+					resource.TestCheckResourceAttr(n, "group.1.dynamic_groups.1.name", "bob"),
+				),
+			},
+			{
+				Config:            testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithDynamicGroups(true, "Alice Smith"),
+				ResourceName:      n,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_pendingDynamicGroupOverride(t *testing.T) {
+	api := fakeapi.NewServer()
+	defer api.Close()
+
+	const n = "cloudhealth_perspective.acc_test_perspective"
+	var created *terraform.State
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCloudHealthPerspectiveDestroy,
+		Steps: []resource.TestStep{
+			{
+				// An override for a value CloudHealth hasn't discovered yet
+				// stays in state, so the plan is empty
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithDynamicGroups(false, "Alice"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.#", "0"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_group.#", "1"),
+					func(s *terraform.State) error {
+						created = s
+						return nil
+					},
+				),
+			},
+			{
+				// and is applied once the value shows up
+				PreConfig: func() {
+					if err := testUnitDiscoverDynamicGroups(api, n, 0, "alice")(created); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testUnitProviderConfig(api) + testUnitCloudHealthPerspectiveWithDynamicGroups(false, "Alice"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.#", "1"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.0.val", "alice"),
+					resource.TestCheckResourceAttr(n, "group.0.dynamic_groups.0.name", "Alice"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudHealthPerspective_dynamicGroupsInConstants(t *testing.T) {
+	// State written before dynamic_groups existed kept the dynamic groups in
+	// the computed constant list only
+	d := resourceCloudHealthPerspective().Data(&terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":                       "My Perspective",
+			"include_in_reports":         "false",
+			"group.#":                    "1",
+			"group.0.name":               "Owner",
+			"group.0.ref_id":             "0",
+			"group.0.type":               "categorize",
+			"group.0.rule.#":             "1",
+			"group.0.rule.0.asset":       "AwsAsset",
+			"group.0.rule.0.tag_field.#": "1",
+			"group.0.rule.0.tag_field.0": "owner",
+			"constant.#":                 "2",
+			"constant.0.constant_type":   cloudhealth.DynamicGroupBlockType,
+			"constant.0.ref_id":          "0",
+			"constant.0.name":            "Owner",
+			"constant.1.constant_type":   cloudhealth.DynamicGroupType,
+			"constant.1.ref_id":          "1",
+			"constant.1.blk_id":          "0",
+			"constant.1.name":            "Alice",
+			"constant.1.val":             "alice",
+		},
+	})
+
+	dynamicGroups := discoveredDynamicGroups(d)
+	expected := map[string][]interface{}{
+		"0": {map[string]interface{}{"ref_id": "1", "name": "Alice", "val": "alice"}},
+	}
+	if !reflect.DeepEqual(dynamicGroups, expected) {
+		t.Fatalf("Expected dynamic groups %v, got %v", expected, dynamicGroups)
+	}
+
+	// Without a dynamic_group override the dynamic group is named after its value
+	perspective, err := convertPerspective(d, dynamicGroups)
+	if err != nil {
+		t.Fatalf("Error converting perspective: %v", err)
+	}
+	var items []cloudhealth.ConstantItem
+	for _, constant := range perspective.Schema.Constants {
+		if constant.Type == cloudhealth.DynamicGroupType {
+			items = append(items, constant.List...)
+		}
+	}
+	if len(items) != 1 || items[0].RefID != "1" || *items[0].BlkID != "0" || items[0].Name != "alice" {
+		t.Fatalf("Expected dynamic group 1 of group 0 named alice, got %v", items)
+	}
+}
+
+func TestUnitCloudHealthPerspective_stateUpgradeV0(t *testing.T) {
+	v0 := map[string]interface{}{
+		"name": "My Perspective",
+		"group": []interface{}{
+			map[string]interface{}{"name": "Team A", "ref_id": "3"},
+			map[string]interface{}{"name": "Team B", "ref_id": "1"},
+		},
+	}
+	expected := map[string]interface{}{
+		"Team A": "3",
+		"Team B": "1",
+	}
+
+	actual, err := resourceCloudHealthPerspectiveStateUpgradeV0(v0, nil)
+	if err != nil {
+		t.Fatalf("Error upgrading state: %v", err)
+	}
+	if !reflect.DeepEqual(actual["group_ref_ids"], expected) {
+		t.Fatalf("Expected group_ref_ids %v, got %v", expected, actual["group_ref_ids"])
+	}
+}
+
 func testAccCheckCloudHealthPerspectiveExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*cloudhealth.Client)
 		for _, r := range s.RootModule().Resources {
 			i := r.Primary.ID
-			if _, err := client.GetPerspective(i); err != nil {
+			if _, err := testAccClientFor(r).GetPerspective(i); err != nil {
 				return err
 			}
 		}
@@ -40,13 +592,67 @@ func testAccCheckCloudHealthPerspectiveExists(n string) resource.TestCheckFunc {
 	}
 }
 
-func testAccCheckCloudHealthPerspectiveDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*cloudhealth.Client)
+// testUnitCheckCloudHealthPerspectiveRuleValues checks the order CloudHealth
+// applies the rules in, by the value of their first condition.
+func testUnitCheckCloudHealthPerspectiveRuleValues(n string, expected ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		perspective, err := testAccClientFor(r).GetPerspective(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+		var actual []string
+		for _, rule := range perspective.Schema.Rules {
+			actual = append(actual, rule.Condition.Clauses[0].Val)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			return fmt.Errorf("Expected rules %v, got %v", expected, actual)
+		}
+		return nil
+	}
+}
 
+func testUnitCheckCloudHealthPerspectiveMerges(n string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		perspective, err := testAccClientFor(r).GetPerspective(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if len(perspective.Schema.Merges) != expected {
+			return fmt.Errorf("Expected %d merges, got %v", expected, perspective.Schema.Merges)
+		}
+		return nil
+	}
+}
+
+// testUnitDiscoverDynamicGroups has the fake API discover a dynamic group for
+// every value in the categorize group at index group.
+func testUnitDiscoverDynamicGroups(api *fakeapi.Server, n string, group int, vals ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		blkID := r.Primary.Attributes[fmt.Sprintf("group.%d.ref_id", group)]
+		for _, val := range vals {
+			api.DiscoverDynamicGroup(r.Primary.ID, blkID, val)
+		}
+		return nil
+	}
+}
+
+func testAccCheckCloudHealthPerspectiveDestroy(s *terraform.State) error {
 	for _, r := range s.RootModule().Resources {
 		i := r.Primary.ID
-		if _, err := client.GetPerspective(i); err != nil {
-			if err == cloudhealth.ErrPerspectiveNotFound {
+		if _, err := testAccClientFor(r).GetPerspective(i); err != nil {
+			if errors.Is(err, cloudhealth.ErrPerspectiveNotFound) {
 				continue
 			}
 			return err
@@ -56,6 +662,25 @@ func testAccCheckCloudHealthPerspectiveDestroy(s *terraform.State) error {
 	return nil
 }
 
+func testAccCheckCloudHealthPerspectiveArchived(s *terraform.State) error {
+	client := testAccProvider.Meta().(*providerMeta).client
+
+	perspectives, err := client.GetAllPerspectives()
+	if err != nil {
+		return err
+	}
+	for _, r := range s.RootModule().Resources {
+		status, ok := (*perspectives)[r.Primary.ID]
+		if !ok {
+			return fmt.Errorf("Perspective %s was deleted instead of archived", r.Primary.ID)
+		}
+		if status.Active {
+			return fmt.Errorf("Perspective %s is still active", r.Primary.ID)
+		}
+	}
+	return nil
+}
+
 func testAccCloudHealthPerspectiveWithDefaults(r string) string {
 	return fmt.Sprintf(`
 resource "cloudhealth_perspective" "acc_test_perspective" {
@@ -74,3 +699,305 @@ resource "cloudhealth_perspective" "acc_test_perspective" {
 }
 `, r)
 }
+
+func testUnitCloudHealthPerspectiveWithClientAPIID(r string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_perspective" "acc_test_perspective" {
+  name               = "%s"
+  include_in_reports = false
+  client_api_id      = 42
+
+  group {
+    name = "Team A"
+
+    rule {
+      asset = "AwsAsset"
+      condition {
+        tag_field = ["team"]
+        val       = "a"
+      }
+    }
+  }
+}
+`, r)
+}
+
+func testUnitCloudHealthPerspectiveWithAdoptArchived(r string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_perspective" "acc_test_perspective" {
+  name               = "%s"
+  include_in_reports = false
+  adopt_archived     = true
+
+  group {
+    name = "OwnerAccTest"
+    type = "categorize"
+
+    rule {
+      asset     = "AwsAsset"
+      tag_field = ["owner"]
+    }
+  }
+}
+`, r)
+}
+
+func testAccCloudHealthPerspectiveWithArchive(r string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_perspective" "acc_test_perspective" {
+  name               = "%s"
+  include_in_reports = false
+  on_destroy         = "archive"
+
+  group {
+    name = "OwnerAccTest"
+    type = "categorize"
+
+    rule {
+      asset     = "AwsAsset"
+      tag_field = ["owner"]
+    }
+  }
+}
+`, r)
+}
+
+func testUnitCloudHealthPerspectiveWithRule(groupType string, rule string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_perspective" "acc_test_perspective" {
+  name               = "invalid"
+  include_in_reports = false
+
+  group {
+    name = "Valid"
+    type = "filter"
+
+    rule {
+      asset = "AwsAsset"
+      condition {
+        tag_field = ["team"]
+        val       = "a"
+      }
+    }
+  }
+
+  group {
+    name = "Invalid"
+    type = "%s"
+
+    rule {
+      asset = "AwsAsset"
+      %s
+    }
+  }
+}
+`, groupType, rule)
+}
+
+func testUnitCloudHealthPerspectiveWithOtherGroup(r string, otherGroupName string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_perspective" "acc_test_perspective" {
+  name               = "%s"
+  include_in_reports = false
+
+  group {
+    name = "Team A"
+
+    rule {
+      asset = "AwsAsset"
+      condition {
+        tag_field = ["team"]
+        val       = "a"
+      }
+    }
+  }
+
+  other_group {
+    name = "%s"
+  }
+}
+`, r, otherGroupName)
+}
+
+func testUnitCloudHealthPerspectiveWithPriorities(r string, specific, fallback, catchAll int) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_perspective" "acc_test_perspective" {
+  name               = "%s"
+  include_in_reports = false
+
+  group {
+    name = "Team A"
+
+    rule {
+      asset    = "AwsAsset"
+      priority = %d
+      condition {
+        tag_field = ["team"]
+        val       = "a-specific"
+      }
+    }
+
+    rule {
+      asset    = "AwsAsset"
+      priority = %d
+      condition {
+        tag_field = ["team"]
+        val       = "a-fallback"
+      }
+    }
+  }
+
+  group {
+    name = "Team B"
+
+    rule {
+      asset    = "AwsAsset"
+      priority = %d
+      condition {
+        tag_field = ["team"]
+        val       = "catch-all"
+      }
+    }
+  }
+}
+`, r, specific, fallback, catchAll)
+}
+
+func testUnitCloudHealthPerspectiveWithMerge(r string, merge string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_perspective" "acc_test_perspective" {
+  name               = "%s"
+  include_in_reports = false
+
+  group {
+    name = "Production"
+
+    rule {
+      asset = "AwsAsset"
+      condition {
+        tag_field = ["env"]
+        val       = "production"
+      }
+    }
+  }
+
+  group {
+    name = "Prod"
+
+    rule {
+      asset = "AwsAsset"
+      condition {
+        tag_field = ["env"]
+        val       = "prod"
+      }
+    }
+  }
+%s
+}
+`, r, merge)
+}
+
+func testUnitCloudHealthPerspectiveWithDynamicGroups(reorder bool, aliceName string) string {
+	owner := `
+  group {
+    name = "Owner"
+    type = "categorize"
+
+    rule {
+      asset     = "AwsAsset"
+      tag_field = ["owner"]
+    }
+`
+	if aliceName != "" {
+		owner += fmt.Sprintf(`
+    dynamic_group {
+      val  = "alice"
+      name = "%s"
+    }
+`, aliceName)
+	}
+	owner += `  }
+`
+	teamA := `
+  group {
+    name = "Team A"
+
+    rule {
+      asset = "AwsAsset"
+      condition {
+        tag_field = ["team"]
+        val       = "a"
+      }
+    }
+  }
+`
+	groups := owner + teamA
+	if reorder {
+		groups = teamA + owner
+	}
+	return fmt.Sprintf(`
+resource "cloudhealth_perspective" "acc_test_perspective" {
+  name               = "dynamic"
+  include_in_reports = false
+%s}
+`, groups)
+}
+
+func testUnitCloudHealthPerspectiveWithKeyedGroups(key1, name1, key2, name2 string) string {
+	return fmt.Sprintf(`
+resource "cloudhealth_perspective" "acc_test_perspective" {
+  name               = "keyed"
+  include_in_reports = false
+
+  group {
+    key  = "%[1]s"
+    name = "%[2]s"
+
+    rule {
+      asset = "AwsAsset"
+      condition {
+        tag_field = ["team"]
+        val       = "%[1]s"
+      }
+    }
+  }
+
+  group {
+    key  = "%[3]s"
+    name = "%[4]s"
+
+    rule {
+      asset = "AwsAsset"
+      condition {
+        tag_field = ["team"]
+        val       = "%[3]s"
+      }
+    }
+  }
+}
+`, key1, name1, key2, name2)
+}
+
+const testUnitPerspectiveMerge = `
+  merge {
+    type = "Static Group"
+    to   = "0"
+    from = ["1"]
+  }
+`
+
+const testUnitPerspectiveUnknownOpRule = `
+      condition {
+        tag_field = ["team"]
+        op        = "Like"
+        val       = "a"
+      }
+`
+
+const testUnitPerspectiveFieldAndTagFieldRule = `
+      condition {
+        tag_field = ["team"]
+        field     = ["Name"]
+        val       = "a"
+      }
+`
@@ -0,0 +1,48 @@
+package cloudhealth
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// validateStringInSlice returns a ValidateFunc that only accepts one of the valid values.
+func validateStringInSlice(valid []string) func(interface{}, string) ([]string, []error) {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		for _, s := range valid {
+			if value == s {
+				return
+			}
+		}
+		errors = append(errors, fmt.Errorf("%q must be one of [%s], got %q", formatPath(k), strings.Join(valid, ", "), value))
+		return
+	}
+}
+
+// formatPath turns a key such as group.3.rule.1.condition.0.op into the
+// group[3].rule[1].condition[0].op form, to point at the offending block.
+func formatPath(k string) string {
+	var b strings.Builder
+	for i, part := range strings.Split(k, ".") {
+		if _, err := strconv.Atoi(part); err == nil && i > 0 {
+			fmt.Fprintf(&b, "[%s]", part)
+			continue
+		}
+		if i > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// validateIntAtLeast returns a ValidateFunc that only accepts integers of at least min.
+func validateIntAtLeast(min int) func(interface{}, string) ([]string, []error) {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		if value := v.(int); value < min {
+			errors = append(errors, fmt.Errorf("%q must be at least %d, got %d", formatPath(k), min, value))
+		}
+		return
+	}
+}
@@ -1,9 +1,11 @@
 module github.com/nextgenhealthcare/terraform-provider-cloudhealth
 
-go 1.12
+go 1.13
 
 require (
 	github.com/hashicorp/hcl v0.0.0-20180404174102-ef8a98b0bbce // indirect
 	github.com/hashicorp/terraform v0.12.0
 	github.com/nextgenhealthcare/cloudhealth-sdk-go v0.0.0-20200413131328-6fb1cd037126
 )
+
+replace github.com/nextgenhealthcare/cloudhealth-sdk-go => ./internal/cloudhealth-sdk-go
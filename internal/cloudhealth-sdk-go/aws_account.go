@@ -0,0 +1,307 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AwsAccount represents the configuration of an AWS Account enabled in CloudHealth.
+type AwsAccount struct {
+	ID               int                      `json:"id"`
+	Name             string                   `json:"name"`
+	OwnerID          string                   `json:"owner_id,omitempty"`
+	Authentication   AwsAccountAuthentication `json:"authentication"`
+	CloudTrail       *AwsAccountCloudTrail    `json:"cloudtrail,omitempty"`
+	AwsConfig        *AwsAccountAwsConfig     `json:"aws_config,omitempty"`
+	CloudWatch       *AwsAccountCloudWatch    `json:"cloudwatch,omitempty"`
+	EnabledRegions   []string                 `json:"enabled_regions,omitempty"`
+	Tags             []AwsAccountTag          `json:"tags,omitempty"`
+	HidePublicFields *bool                    `json:"hide_public_fields,omitempty"`
+	ClusterName      string                   `json:"cluster_name,omitempty"`
+}
+
+// MarshalJSON leaves out nil EnabledRegions and Tags, so CloudHealth keeps
+// the ones it has, but sends empty non-nil ones as [] to clear them.
+func (a AwsAccount) MarshalJSON() ([]byte, error) {
+	type awsAccount AwsAccount
+	v := struct {
+		awsAccount
+		EnabledRegions *[]string        `json:"enabled_regions,omitempty"`
+		Tags           *[]AwsAccountTag `json:"tags,omitempty"`
+	}{awsAccount: awsAccount(a)}
+	if a.EnabledRegions != nil {
+		v.EnabledRegions = &a.EnabledRegions
+	}
+	if a.Tags != nil {
+		v.Tags = &a.Tags
+	}
+	return json.Marshal(v)
+}
+
+// AwsAccounts is a structure to unmarshal CloudHealth GET accounts results into
+type AwsAccounts struct {
+	Accounts []AwsAccount `json:"aws_accounts"`
+}
+
+// AwsAccountAuthentication represents the authentication details for AWS integration.
+type AwsAccountAuthentication struct {
+	Protocol             string `json:"protocol"`
+	AccessKey            string `json:"access_key,omitempty"`
+	SecreyKey            string `json:"secret_key,omitempty"`
+	AssumeRoleArn        string `json:"assume_role_arn,omitempty"`
+	AssumeRoleExternalID string `json:"assume_role_external_id,omitempty"`
+}
+
+// AwsAccountCloudTrail represents the S3 bucket CloudHealth collects CloudTrail logs from.
+type AwsAccountCloudTrail struct {
+	Enabled bool   `json:"enabled"`
+	Bucket  string `json:"bucket,omitempty"`
+	Prefix  string `json:"prefix,omitempty"`
+}
+
+// AwsAccountAwsConfig represents the S3 bucket CloudHealth collects AWS Config snapshots from.
+type AwsAccountAwsConfig struct {
+	Enabled bool   `json:"enabled"`
+	Bucket  string `json:"bucket,omitempty"`
+	Prefix  string `json:"prefix,omitempty"`
+}
+
+// AwsAccountCloudWatch represents whether CloudHealth collects CloudWatch metrics.
+type AwsAccountCloudWatch struct {
+	Enabled bool `json:"enabled"`
+}
+
+// AwsAccountTag is a key/value pair CloudHealth attaches to the AWS Account.
+type AwsAccountTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ErrAwsAccountNotFound is returned when an AWS Account doesn't exist on a Read or Delete.
+// It's useful for ignoring errors (e.g. delete if exists).
+var ErrAwsAccountNotFound = errors.New("AWS Account not found")
+
+// getPaginatedAwsAccounts retrieves a page of results for the GetAllAwsAccounts function
+func (s *Client) getPaginatedAwsAccounts(req *http.Request, page, perPage int) (*AwsAccounts, error) {
+	var accountsPage = new(AwsAccounts)
+
+	q := req.URL.Query()
+	q.Set("per_page", strconv.Itoa(perPage))
+	q.Set("page", strconv.Itoa(page))
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		err = json.Unmarshal(responseBody, &accountsPage)
+		if err != nil {
+			return nil, err
+		}
+		return accountsPage, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrAwsAccountNotFound)
+	}
+}
+
+// GetAllAwsAccounts gets all AWS Accounts
+func (s *Client) GetAllAwsAccounts(perPage int) ([]AwsAccount, error) {
+	return s.GetAllAwsAccountsWithContext(context.Background(), perPage)
+}
+
+// GetAllAwsAccountsWithContext is like GetAllAwsAccounts but carries ctx through the request and any retries.
+func (s *Client) GetAllAwsAccountsWithContext(ctx context.Context, perPage int) ([]AwsAccount, error) {
+	var accounts []AwsAccount
+
+	relativeURL, _ := url.Parse("aws_accounts")
+	apiUrl := s.EndpointURL.ResolveReference(relativeURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get Paginated results for AWS accounts endpoint
+	// CloudHealth starts counting pages at 1 (but also accepts 0 which has results identical to 1)
+	for pageNo, pageLen := 1, perPage; pageLen == perPage; pageNo++ {
+		accountsPage, err := s.getPaginatedAwsAccounts(req, pageNo, perPage)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, accountsPage.Accounts...)
+		pageLen = len(accountsPage.Accounts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// GetAwsAccount gets the AWS Account with the specified CloudHealth Account ID.
+func (s *Client) GetAwsAccount(id int) (*AwsAccount, error) {
+	return s.GetAwsAccountWithContext(context.Background(), id)
+}
+
+// GetAwsAccountWithContext is like GetAwsAccount but carries ctx through the request and any retries.
+func (s *Client) GetAwsAccountWithContext(ctx context.Context, id int) (*AwsAccount, error) {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts/%d", id))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var account = new(AwsAccount)
+		err = json.Unmarshal(responseBody, &account)
+		if err != nil {
+			return nil, err
+		}
+
+		return account, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrAwsAccountNotFound)
+	}
+}
+
+// CreateAwsAccount enables a new AWS Account in CloudHealth.
+func (s *Client) CreateAwsAccount(account AwsAccount) (*AwsAccount, error) {
+	return s.CreateAwsAccountWithContext(context.Background(), account)
+}
+
+// CreateAwsAccountWithContext is like CreateAwsAccount but carries ctx through the request and any retries.
+func (s *Client) CreateAwsAccountWithContext(ctx context.Context, account AwsAccount) (*AwsAccount, error) {
+
+	body, _ := json.Marshal(account)
+
+	relativeURL, _ := url.Parse("aws_accounts")
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), bytes.NewBuffer(body))
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		var account = new(AwsAccount)
+		err = json.Unmarshal(responseBody, &account)
+		if err != nil {
+			return nil, err
+		}
+
+		return account, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrAwsAccountNotFound)
+	}
+}
+
+// UpdateAwsAccount updates an existing AWS Account in CloudHealth.
+func (s *Client) UpdateAwsAccount(account AwsAccount) (*AwsAccount, error) {
+	return s.UpdateAwsAccountWithContext(context.Background(), account)
+}
+
+// UpdateAwsAccountWithContext is like UpdateAwsAccount but carries ctx through the request and any retries.
+func (s *Client) UpdateAwsAccountWithContext(ctx context.Context, account AwsAccount) (*AwsAccount, error) {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts/%d", account.ID))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	body, _ := json.Marshal(account)
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url.String(), bytes.NewBuffer((body)))
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var account = new(AwsAccount)
+		err = json.Unmarshal(responseBody, &account)
+		if err != nil {
+			return nil, err
+		}
+
+		return account, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrAwsAccountNotFound)
+	}
+}
+
+// DeleteAwsAccount removes the AWS Account with the specified CloudHealth ID.
+func (s *Client) DeleteAwsAccount(id int) error {
+	return s.DeleteAwsAccountWithContext(context.Background(), id)
+}
+
+// DeleteAwsAccountWithContext is like DeleteAwsAccount but carries ctx through the request and any retries.
+func (s *Client) DeleteAwsAccountWithContext(ctx context.Context, id int) error {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("aws_accounts/%d", id))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url.String(), nil)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNoContent:
+		return nil
+	default:
+		return newAPIError(resp, responseBody, ErrAwsAccountNotFound)
+	}
+}
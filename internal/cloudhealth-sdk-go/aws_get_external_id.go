@@ -0,0 +1,52 @@
+package cloudhealth
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// AwsExternalID is used to enable integration with AWS via IAM Roles.
+type AwsExternalID struct {
+	ExternalID string `json:"generated_external_id"`
+}
+
+// GetAwsExternalID gets the AWS External ID tied to the CloudHealth Account.
+func (s *Client) GetAwsExternalID() (string, error) {
+	return s.GetAwsExternalIDWithContext(context.Background())
+}
+
+// GetAwsExternalIDWithContext is like GetAwsExternalID but carries ctx through the request and any retries.
+func (s *Client) GetAwsExternalIDWithContext(ctx context.Context) (string, error) {
+
+	relativeURL, _ := url.Parse("aws_accounts/:id/generate_external_id")
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
+
+	resp, err := s.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var id = new(AwsExternalID)
+		err = json.Unmarshal(responseBody, &id)
+		if err != nil {
+			return "", err
+		}
+
+		return id.ExternalID, nil
+	default:
+		return "", newAPIError(resp, responseBody, nil)
+	}
+}
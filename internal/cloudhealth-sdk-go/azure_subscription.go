@@ -0,0 +1,256 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AzureSubscription represents the configuration of an Azure Subscription enabled in CloudHealth.
+type AzureSubscription struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	AzureID  string `json:"azure_id"`
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+// AzureSubscriptions is a structure to unmarshal CloudHealth GET subscriptions results into
+type AzureSubscriptions struct {
+	Subscriptions []AzureSubscription `json:"azure_subscriptions"`
+}
+
+// ErrAzureSubscriptionNotFound is returned when an Azure Subscription doesn't exist on a Read or Delete.
+// It's useful for ignoring errors (e.g. delete if exists).
+var ErrAzureSubscriptionNotFound = errors.New("Azure Subscription not found")
+
+// getPaginatedAzureSubscriptions retrieves a page of results for the GetAllAzureSubscriptions function
+func (s *Client) getPaginatedAzureSubscriptions(req *http.Request, page, perPage int) (*AzureSubscriptions, error) {
+	var subscriptionsPage = new(AzureSubscriptions)
+
+	q := req.URL.Query()
+	q.Set("per_page", strconv.Itoa(perPage))
+	q.Set("page", strconv.Itoa(page))
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		err = json.Unmarshal(responseBody, &subscriptionsPage)
+		if err != nil {
+			return nil, err
+		}
+		return subscriptionsPage, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrAzureSubscriptionNotFound)
+	}
+}
+
+// GetAllAzureSubscriptions gets all Azure Subscriptions
+func (s *Client) GetAllAzureSubscriptions(perPage int) ([]AzureSubscription, error) {
+	return s.GetAllAzureSubscriptionsWithContext(context.Background(), perPage)
+}
+
+// GetAllAzureSubscriptionsWithContext is like GetAllAzureSubscriptions but carries ctx through the request and any retries.
+func (s *Client) GetAllAzureSubscriptionsWithContext(ctx context.Context, perPage int) ([]AzureSubscription, error) {
+	var subscriptions []AzureSubscription
+
+	relativeURL, _ := url.Parse("azure_subscriptions")
+	apiUrl := s.EndpointURL.ResolveReference(relativeURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get Paginated results for Azure subscriptions endpoint
+	for pageNo, pageLen := 1, perPage; pageLen == perPage; pageNo++ {
+		subscriptionsPage, err := s.getPaginatedAzureSubscriptions(req, pageNo, perPage)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscriptionsPage.Subscriptions...)
+		pageLen = len(subscriptionsPage.Subscriptions)
+	}
+	return subscriptions, nil
+}
+
+// GetAzureSubscription gets the Azure Subscription with the specified CloudHealth Subscription ID.
+func (s *Client) GetAzureSubscription(id int) (*AzureSubscription, error) {
+	return s.GetAzureSubscriptionWithContext(context.Background(), id)
+}
+
+// GetAzureSubscriptionWithContext is like GetAzureSubscription but carries ctx through the request and any retries.
+func (s *Client) GetAzureSubscriptionWithContext(ctx context.Context, id int) (*AzureSubscription, error) {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("azure_subscriptions/%d", id))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var subscription = new(AzureSubscription)
+		err = json.Unmarshal(responseBody, &subscription)
+		if err != nil {
+			return nil, err
+		}
+
+		return subscription, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrAzureSubscriptionNotFound)
+	}
+}
+
+// CreateAzureSubscription enables a new Azure Subscription in CloudHealth.
+func (s *Client) CreateAzureSubscription(subscription AzureSubscription) (*AzureSubscription, error) {
+	return s.CreateAzureSubscriptionWithContext(context.Background(), subscription)
+}
+
+// CreateAzureSubscriptionWithContext is like CreateAzureSubscription but carries ctx through the request and any retries.
+func (s *Client) CreateAzureSubscriptionWithContext(ctx context.Context, subscription AzureSubscription) (*AzureSubscription, error) {
+
+	body, _ := json.Marshal(subscription)
+
+	relativeURL, _ := url.Parse("azure_subscriptions")
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		var subscription = new(AzureSubscription)
+		err = json.Unmarshal(responseBody, &subscription)
+		if err != nil {
+			return nil, err
+		}
+
+		return subscription, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrAzureSubscriptionNotFound)
+	}
+}
+
+// UpdateAzureSubscription updates an existing Azure Subscription in CloudHealth.
+func (s *Client) UpdateAzureSubscription(subscription AzureSubscription) (*AzureSubscription, error) {
+	return s.UpdateAzureSubscriptionWithContext(context.Background(), subscription)
+}
+
+// UpdateAzureSubscriptionWithContext is like UpdateAzureSubscription but carries ctx through the request and any retries.
+func (s *Client) UpdateAzureSubscriptionWithContext(ctx context.Context, subscription AzureSubscription) (*AzureSubscription, error) {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("azure_subscriptions/%d", subscription.ID))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	body, _ := json.Marshal(subscription)
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var subscription = new(AzureSubscription)
+		err = json.Unmarshal(responseBody, &subscription)
+		if err != nil {
+			return nil, err
+		}
+
+		return subscription, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrAzureSubscriptionNotFound)
+	}
+}
+
+// DeleteAzureSubscription removes the Azure Subscription with the specified CloudHealth ID.
+func (s *Client) DeleteAzureSubscription(id int) error {
+	return s.DeleteAzureSubscriptionWithContext(context.Background(), id)
+}
+
+// DeleteAzureSubscriptionWithContext is like DeleteAzureSubscription but carries ctx through the request and any retries.
+func (s *Client) DeleteAzureSubscriptionWithContext(ctx context.Context, id int) error {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("azure_subscriptions/%d", id))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNoContent:
+		return nil
+	default:
+		return newAPIError(resp, responseBody, ErrAzureSubscriptionNotFound)
+	}
+}
@@ -0,0 +1,181 @@
+// Package cloudhealth is a wrapper for the CloudHealth API.
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var defaultTimeout int = 15
+
+// Client communicates with the CloudHealth API.
+type Client struct {
+	ApiKey      string
+	EndpointURL *url.URL
+	// Timeout is the timeout of each request, in seconds. Zero leaves
+	// requests to the http.Client's own timeout.
+	Timeout int
+
+	// MaxRetries is how many times a throttled or transiently failed request is retried.
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// RetryNonIdempotent opts requests such as POST into retries.
+	RetryNonIdempotent bool
+
+	// ClientAPIID scopes requests to a partner customer tenant, see
+	// WithClientAPIID. Zero means the partner's own tenant.
+	ClientAPIID int
+
+	// httpClient is shared by every request, so connections are reused.
+	httpClient *http.Client
+	transport  http.RoundTripper
+	userAgent  string
+	limiter    *rateLimiter
+	logger     Logger
+	// timeoutSet tells whether WithTimeout was given, which takes
+	// precedence over the timeout of a WithHTTPClient client.
+	timeoutSet bool
+}
+
+// ErrClientAuthenticationError is returned for authentication errors with the API.
+var ErrClientAuthenticationError = errors.New("Authentication Error with CloudHealth")
+
+// NewClient returns a new cloudhealth.Client for accessing the CloudHealth API.
+//
+// The timeout used to be an optional int argument:
+// NewClient(apiKey, url, 30) is now NewClient(apiKey, url, WithTimeout(30)).
+func NewClient(apiKey string, defaultEndpointURL string, opts ...Option) (*Client, error) {
+	s := &Client{
+		ApiKey: apiKey,
+	}
+	endpointURL, err := url.Parse(defaultEndpointURL)
+	if err != nil {
+		return nil, err
+	}
+	s.EndpointURL = endpointURL
+	s.Timeout = defaultTimeout
+	s.MaxRetries = defaultMaxRetries
+	s.RetryWaitMin = defaultRetryWaitMin
+	s.RetryWaitMax = defaultRetryWaitMax
+	s.userAgent = defaultUserAgent
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.httpClient == nil {
+		s.httpClient = &http.Client{}
+	} else if !s.timeoutSet {
+		s.Timeout = 0
+	}
+	if s.transport != nil {
+		httpClient := *s.httpClient
+		httpClient.Transport = s.transport
+		s.httpClient = &httpClient
+	}
+	if s.logger != nil {
+		httpClient := *s.httpClient
+		httpClient.Transport = NewLoggingTransport(httpClient.Transport, s.logger)
+		s.httpClient = &httpClient
+	}
+	return s, nil
+}
+
+// WithClientAPIID returns a copy of the client whose requests act on behalf of
+// the partner customer with the given CloudHealth ID, e.g. a Customer.ID.
+func (s *Client) WithClientAPIID(id int) *Client {
+	scoped := *s
+	scoped.ClientAPIID = id
+	return &scoped
+}
+
+// do sends a request to CloudHealth with the client's timeout and credentials.
+//
+// Throttled requests (429) and transient failures (connection errors, 502, 503
+// and 504) are retried for idempotent methods only, unless RetryNonIdempotent
+// is set. Retries back off exponentially with jitter between RetryWaitMin and
+// RetryWaitMax, and honor the Retry-After header when CloudHealth sends one.
+// Cancelling the request's context aborts both the request in flight and the
+// wait between retries.
+// Every attempt counts against the rate limit set with WithRateLimit.
+func (s *Client) do(req *http.Request) (*http.Response, error) {
+	// Copying the http.Client is cheap, and the copy shares its transport
+	// and connection pool
+	client := *s.httpClient
+	if s.Timeout > 0 {
+		client.Timeout = time.Second * time.Duration(s.Timeout)
+	}
+
+	// The API key goes in a header rather than the query string, so that it
+	// doesn't end up in proxy logs or error messages
+	req.Header.Set("Authorization", "Bearer "+s.ApiKey)
+	if s.userAgent != "" {
+		req.Header.Set("User-Agent", s.userAgent)
+	}
+
+	if s.ClientAPIID != 0 {
+		q := req.URL.Query()
+		q.Set("client_api_id", strconv.Itoa(s.ClientAPIID))
+		req.URL.RawQuery = q.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		if s.limiter != nil {
+			if err := s.limiter.wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := client.Do(req)
+		if urlErr, ok := err.(*url.Error); ok {
+			urlErr.URL = redactURL(req.URL)
+		}
+		if attempt >= s.MaxRetries || !s.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := s.backoff(attempt, resp)
+		if wait < 0 {
+			// CloudHealth asked us to wait longer than we are willing to
+			return resp, err
+		}
+		if resp != nil {
+			drainBody(resp.Body)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// redactedValue replaces secrets in URLs and requests that are logged or returned in errors.
+const redactedValue = "REDACTED"
+
+// redactURL returns the URL as a string with the API key masked, should it
+// have been passed in the query string (e.g. as part of the endpoint URL).
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	if _, ok := q["api_key"]; !ok {
+		return u.String()
+	}
+	redacted := *u
+	q.Set("api_key", redactedValue)
+	redacted.RawQuery = q.Encode()
+	return redacted.String()
+}
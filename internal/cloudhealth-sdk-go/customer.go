@@ -0,0 +1,275 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Customer represents a partner customer tenant in CloudHealth.
+type Customer struct {
+	ID                          int                                  `json:"id"`
+	Name                        string                               `json:"name"`
+	Classification              string                               `json:"classification,omitempty"`
+	TrialExpirationDate         string                               `json:"trial_expiration_date,omitempty"`
+	BillingContact              string                               `json:"billing_contact,omitempty"`
+	Address                     CustomerAddress                      `json:"address"`
+	PartnerBillingConfiguration *CustomerPartnerBillingConfiguration `json:"partner_billing_configuration,omitempty"`
+}
+
+// Customers is a structure to unmarshal CloudHealth GET customers results into
+type Customers struct {
+	Customers []Customer `json:"customers"`
+}
+
+// CustomerAddress represents the postal address of a partner customer.
+type CustomerAddress struct {
+	Street1 string `json:"street1"`
+	Street2 string `json:"street2,omitempty"`
+	City    string `json:"city"`
+	State   string `json:"state"`
+	ZipCode string `json:"zipcode"`
+	Country string `json:"country"`
+}
+
+// CustomerPartnerBillingConfiguration represents where the partner bills the customer from.
+type CustomerPartnerBillingConfiguration struct {
+	Enabled bool   `json:"enabled"`
+	Folder  string `json:"folder,omitempty"`
+}
+
+// ErrCustomerNotFound is returned when a Customer doesn't exist on a Read or Delete.
+// It's useful for ignoring errors (e.g. delete if exists).
+var ErrCustomerNotFound = errors.New("Customer not found")
+
+// getPaginatedCustomers retrieves a page of results for the GetAllCustomers function
+func (s *Client) getPaginatedCustomers(req *http.Request, page, perPage int) (*Customers, error) {
+	var customersPage = new(Customers)
+
+	q := req.URL.Query()
+	q.Set("per_page", strconv.Itoa(perPage))
+	q.Set("page", strconv.Itoa(page))
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		err = json.Unmarshal(responseBody, &customersPage)
+		if err != nil {
+			return nil, err
+		}
+		return customersPage, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrCustomerNotFound)
+	}
+}
+
+// GetAllCustomers gets all partner Customers
+func (s *Client) GetAllCustomers(perPage int) ([]Customer, error) {
+	return s.GetAllCustomersWithContext(context.Background(), perPage)
+}
+
+// GetAllCustomersWithContext is like GetAllCustomers but carries ctx through the request and any retries.
+func (s *Client) GetAllCustomersWithContext(ctx context.Context, perPage int) ([]Customer, error) {
+	var customers []Customer
+
+	relativeURL, _ := url.Parse("customers")
+	apiUrl := s.EndpointURL.ResolveReference(relativeURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get Paginated results for partner customers endpoint
+	for pageNo, pageLen := 1, perPage; pageLen == perPage; pageNo++ {
+		customersPage, err := s.getPaginatedCustomers(req, pageNo, perPage)
+		if err != nil {
+			return nil, err
+		}
+		customers = append(customers, customersPage.Customers...)
+		pageLen = len(customersPage.Customers)
+	}
+	return customers, nil
+}
+
+// GetCustomer gets the partner Customer with the specified CloudHealth Customer ID.
+func (s *Client) GetCustomer(id int) (*Customer, error) {
+	return s.GetCustomerWithContext(context.Background(), id)
+}
+
+// GetCustomerWithContext is like GetCustomer but carries ctx through the request and any retries.
+func (s *Client) GetCustomerWithContext(ctx context.Context, id int) (*Customer, error) {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("customers/%d", id))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var customer = new(Customer)
+		err = json.Unmarshal(responseBody, &customer)
+		if err != nil {
+			return nil, err
+		}
+
+		return customer, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrCustomerNotFound)
+	}
+}
+
+// CreateCustomer creates a new partner Customer in CloudHealth.
+func (s *Client) CreateCustomer(customer Customer) (*Customer, error) {
+	return s.CreateCustomerWithContext(context.Background(), customer)
+}
+
+// CreateCustomerWithContext is like CreateCustomer but carries ctx through the request and any retries.
+func (s *Client) CreateCustomerWithContext(ctx context.Context, customer Customer) (*Customer, error) {
+
+	body, _ := json.Marshal(customer)
+
+	relativeURL, _ := url.Parse("customers")
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		var customer = new(Customer)
+		err = json.Unmarshal(responseBody, &customer)
+		if err != nil {
+			return nil, err
+		}
+
+		return customer, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrCustomerNotFound)
+	}
+}
+
+// UpdateCustomer updates an existing partner Customer in CloudHealth.
+func (s *Client) UpdateCustomer(customer Customer) (*Customer, error) {
+	return s.UpdateCustomerWithContext(context.Background(), customer)
+}
+
+// UpdateCustomerWithContext is like UpdateCustomer but carries ctx through the request and any retries.
+func (s *Client) UpdateCustomerWithContext(ctx context.Context, customer Customer) (*Customer, error) {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("customers/%d", customer.ID))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	body, _ := json.Marshal(customer)
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var customer = new(Customer)
+		err = json.Unmarshal(responseBody, &customer)
+		if err != nil {
+			return nil, err
+		}
+
+		return customer, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrCustomerNotFound)
+	}
+}
+
+// DeleteCustomer removes the partner Customer with the specified CloudHealth ID.
+func (s *Client) DeleteCustomer(id int) error {
+	return s.DeleteCustomerWithContext(context.Background(), id)
+}
+
+// DeleteCustomerWithContext is like DeleteCustomer but carries ctx through the request and any retries.
+func (s *Client) DeleteCustomerWithContext(ctx context.Context, id int) error {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("customers/%d", id))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNoContent:
+		return nil
+	default:
+		return newAPIError(resp, responseBody, ErrCustomerNotFound)
+	}
+}
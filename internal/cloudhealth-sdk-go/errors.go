@@ -0,0 +1,125 @@
+package cloudhealth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// maxRawErrorMessage bounds how much of a non-JSON error body ends up in an APIError.
+const maxRawErrorMessage = 512
+
+// APIError is returned when CloudHealth responds with an unexpected status code.
+//
+// It carries the error messages CloudHealth sent back, so callers can show
+// why a request was rejected. Use errors.As to inspect it, and errors.Is to
+// match it against sentinels such as ErrAwsAccountNotFound or
+// ErrClientAuthenticationError.
+type APIError struct {
+	StatusCode int
+	Method     string
+	// Endpoint is the URL of the request, with any secret redacted.
+	Endpoint string
+	// Messages are the error messages from the response body.
+	Messages []string
+	// RequestID identifies the request in CloudHealth, for support cases.
+	RequestID string
+
+	// err is the sentinel the response maps to, if any.
+	err error
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.err != nil {
+		fmt.Fprintf(&b, "%s: ", e.err)
+	}
+	fmt.Fprintf(&b, "CloudHealth responded `%d` to %s %s", e.StatusCode, e.Method, e.Endpoint)
+	if len(e.Messages) > 0 {
+		fmt.Fprintf(&b, ": %s", strings.Join(e.Messages, "; "))
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// Unwrap returns the sentinel error the response maps to, if any.
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// newAPIError builds the error for an unexpected response. notFound is the
+// sentinel returned through errors.Is for a 404, e.g. ErrAwsAccountNotFound.
+func newAPIError(resp *http.Response, body []byte, notFound error) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Messages:   parseErrorMessages(body),
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		e.Endpoint = redactURL(resp.Request.URL)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		e.err = ErrClientAuthenticationError
+	case http.StatusNotFound:
+		e.err = notFound
+	}
+	return e
+}
+
+// parseErrorMessages extracts the error messages from a CloudHealth error
+// body, which comes as {"error": "..."} or {"errors": [...]} with either
+// strings or per-field lists of strings. Anything else is kept as raw text.
+func parseErrorMessages(body []byte) []string {
+	trimmed := strings.TrimSpace(string(body))
+	if trimmed == "" {
+		return nil
+	}
+
+	var parsed struct {
+		Error  interface{} `json:"error"`
+		Errors interface{} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil || (parsed.Error == nil && parsed.Errors == nil) {
+		if len(trimmed) > maxRawErrorMessage {
+			trimmed = trimmed[:maxRawErrorMessage] + "..."
+		}
+		return []string{trimmed}
+	}
+
+	return append(flattenErrorMessages("", parsed.Error), flattenErrorMessages("", parsed.Errors)...)
+}
+
+func flattenErrorMessages(prefix string, v interface{}) []string {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{prefix + v}
+	case []interface{}:
+		var messages []string
+		for _, item := range v {
+			messages = append(messages, flattenErrorMessages(prefix, item)...)
+		}
+		return messages
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var messages []string
+		for _, k := range keys {
+			messages = append(messages, flattenErrorMessages(prefix+k+": ", v[k])...)
+		}
+		return messages
+	default:
+		return []string{fmt.Sprintf("%s%v", prefix, v)}
+	}
+}
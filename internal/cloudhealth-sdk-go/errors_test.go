@@ -0,0 +1,109 @@
+package cloudhealth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorMessages(t *testing.T) {
+	cases := []struct {
+		body     string
+		expected []string
+	}{
+		{"", nil},
+		{"  \n", nil},
+		{`{"error": "Record not found"}`, []string{"Record not found"}},
+		{`{"errors": ["Name has already been taken", "Invalid role"]}`, []string{"Name has already been taken", "Invalid role"}},
+		{
+			`{"errors": {"name": ["can't be blank"], "authentication": {"role_arn": ["is invalid"]}}}`,
+			[]string{"authentication: role_arn: is invalid", "name: can't be blank"},
+		},
+		{`{"error": "Bad", "errors": ["Worse"]}`, []string{"Bad", "Worse"}},
+		{`{"errors": [42]}`, []string{"42"}},
+		{`<html>Bad Gateway</html>`, []string{"<html>Bad Gateway</html>"}},
+		{`{"message": "not the usual shape"}`, []string{`{"message": "not the usual shape"}`}},
+		{strings.Repeat("x", maxRawErrorMessage+1), []string{strings.Repeat("x", maxRawErrorMessage) + "..."}},
+	}
+
+	for i, c := range cases {
+		if actual := parseErrorMessages([]byte(c.body)); !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Case %d: expected %q, got %q", i, c.expected, actual)
+		}
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	errOther := errors.New("other")
+	cases := []struct {
+		statusCode int
+		notFound   error
+		expected   error
+	}{
+		{http.StatusNotFound, ErrPerspectiveNotFound, ErrPerspectiveNotFound},
+		{http.StatusNotFound, ErrAwsAccountNotFound, ErrAwsAccountNotFound},
+		{http.StatusNotFound, nil, nil},
+		{http.StatusUnauthorized, ErrPerspectiveNotFound, ErrClientAuthenticationError},
+		{http.StatusForbidden, ErrPerspectiveNotFound, ErrClientAuthenticationError},
+		{http.StatusUnprocessableEntity, ErrPerspectiveNotFound, nil},
+		{http.StatusInternalServerError, errOther, nil},
+	}
+
+	for i, c := range cases {
+		req := &http.Request{Method: "GET", URL: &url.URL{Scheme: "https", Host: "chapi.cloudhealthtech.com", Path: "/v1/x", RawQuery: "api_key=secret"}}
+		resp := &http.Response{StatusCode: c.statusCode, Header: http.Header{"X-Request-Id": {"abc"}}, Request: req}
+		err := error(newAPIError(resp, []byte(`{"error": "nope"}`), c.notFound))
+
+		for _, sentinel := range []error{ErrPerspectiveNotFound, ErrAwsAccountNotFound, ErrClientAuthenticationError, errOther} {
+			if is := errors.Is(err, sentinel); is != (sentinel == c.expected) {
+				t.Errorf("Case %d: errors.Is(%q) is %t", i, sentinel, is)
+			}
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Case %d: expected an *APIError, got %T", i, err)
+		}
+		if apiErr.StatusCode != c.statusCode || apiErr.Method != "GET" || apiErr.RequestID != "abc" {
+			t.Errorf("Case %d: unexpected %#v", i, apiErr)
+		}
+		if message := err.Error(); !strings.Contains(message, "nope") || !strings.Contains(message, "(request ID abc)") || strings.Contains(message, "secret") {
+			t.Errorf("Case %d: unexpected message %q", i, message)
+		}
+	}
+}
+
+func TestNotFoundErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "Record not found"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient("apiKey", ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		get      func() error
+		expected error
+	}{
+		{"aws account", func() error { _, err := c.GetAwsAccount(1); return err }, ErrAwsAccountNotFound},
+		{"azure subscription", func() error { _, err := c.GetAzureSubscription(1); return err }, ErrAzureSubscriptionNotFound},
+		{"customer", func() error { _, err := c.GetCustomer(1); return err }, ErrCustomerNotFound},
+		{"gcp project", func() error { _, err := c.GetGcpProject(1); return err }, ErrGcpProjectNotFound},
+		{"perspective", func() error { _, err := c.GetPerspective("1"); return err }, ErrPerspectiveNotFound},
+	}
+
+	for _, c := range cases {
+		if err := c.get(); !errors.Is(err, c.expected) {
+			t.Errorf("%s: expected %q, got %v", c.name, c.expected, err)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// GcpProject represents the configuration of a GCP Compute Project enabled in CloudHealth.
+type GcpProject struct {
+	ID          int                   `json:"id"`
+	Name        string                `json:"name"`
+	ProjectID   string                `json:"project_id"`
+	Credentials GcpProjectCredentials `json:"service_account"`
+}
+
+// GcpProjects is a structure to unmarshal CloudHealth GET projects results into
+type GcpProjects struct {
+	Projects []GcpProject `json:"gcp_compute_projects"`
+}
+
+// GcpProjectCredentials represents the service account CloudHealth uses to collect from the GCP Project.
+type GcpProjectCredentials struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key,omitempty"`
+}
+
+// ErrGcpProjectNotFound is returned when a GCP Project doesn't exist on a Read or Delete.
+// It's useful for ignoring errors (e.g. delete if exists).
+var ErrGcpProjectNotFound = errors.New("GCP Project not found")
+
+// getPaginatedGcpProjects retrieves a page of results for the GetAllGcpProjects function
+func (s *Client) getPaginatedGcpProjects(req *http.Request, page, perPage int) (*GcpProjects, error) {
+	var projectsPage = new(GcpProjects)
+
+	q := req.URL.Query()
+	q.Set("per_page", strconv.Itoa(perPage))
+	q.Set("page", strconv.Itoa(page))
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		err = json.Unmarshal(responseBody, &projectsPage)
+		if err != nil {
+			return nil, err
+		}
+		return projectsPage, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrGcpProjectNotFound)
+	}
+}
+
+// GetAllGcpProjects gets all GCP Projects
+func (s *Client) GetAllGcpProjects(perPage int) ([]GcpProject, error) {
+	return s.GetAllGcpProjectsWithContext(context.Background(), perPage)
+}
+
+// GetAllGcpProjectsWithContext is like GetAllGcpProjects but carries ctx through the request and any retries.
+func (s *Client) GetAllGcpProjectsWithContext(ctx context.Context, perPage int) ([]GcpProject, error) {
+	var projects []GcpProject
+
+	relativeURL, _ := url.Parse("gcp_compute_projects")
+	apiUrl := s.EndpointURL.ResolveReference(relativeURL)
+	req, err := http.NewRequestWithContext(ctx, "GET", apiUrl.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get Paginated results for GCP compute projects endpoint
+	for pageNo, pageLen := 1, perPage; pageLen == perPage; pageNo++ {
+		projectsPage, err := s.getPaginatedGcpProjects(req, pageNo, perPage)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, projectsPage.Projects...)
+		pageLen = len(projectsPage.Projects)
+	}
+	return projects, nil
+}
+
+// GetGcpProject gets the GCP Project with the specified CloudHealth Project ID.
+func (s *Client) GetGcpProject(id int) (*GcpProject, error) {
+	return s.GetGcpProjectWithContext(context.Background(), id)
+}
+
+// GetGcpProjectWithContext is like GetGcpProject but carries ctx through the request and any retries.
+func (s *Client) GetGcpProjectWithContext(ctx context.Context, id int) (*GcpProject, error) {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("gcp_compute_projects/%d", id))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var project = new(GcpProject)
+		err = json.Unmarshal(responseBody, &project)
+		if err != nil {
+			return nil, err
+		}
+
+		return project, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrGcpProjectNotFound)
+	}
+}
+
+// CreateGcpProject enables a new GCP Project in CloudHealth.
+func (s *Client) CreateGcpProject(project GcpProject) (*GcpProject, error) {
+	return s.CreateGcpProjectWithContext(context.Background(), project)
+}
+
+// CreateGcpProjectWithContext is like CreateGcpProject but carries ctx through the request and any retries.
+func (s *Client) CreateGcpProjectWithContext(ctx context.Context, project GcpProject) (*GcpProject, error) {
+
+	body, _ := json.Marshal(project)
+
+	relativeURL, _ := url.Parse("gcp_compute_projects")
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		var project = new(GcpProject)
+		err = json.Unmarshal(responseBody, &project)
+		if err != nil {
+			return nil, err
+		}
+
+		return project, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrGcpProjectNotFound)
+	}
+}
+
+// UpdateGcpProject updates an existing GCP Project in CloudHealth.
+func (s *Client) UpdateGcpProject(project GcpProject) (*GcpProject, error) {
+	return s.UpdateGcpProjectWithContext(context.Background(), project)
+}
+
+// UpdateGcpProjectWithContext is like UpdateGcpProject but carries ctx through the request and any retries.
+func (s *Client) UpdateGcpProjectWithContext(ctx context.Context, project GcpProject) (*GcpProject, error) {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("gcp_compute_projects/%d", project.ID))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	body, _ := json.Marshal(project)
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", url.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var project = new(GcpProject)
+		err = json.Unmarshal(responseBody, &project)
+		if err != nil {
+			return nil, err
+		}
+
+		return project, nil
+	default:
+		return nil, newAPIError(resp, responseBody, ErrGcpProjectNotFound)
+	}
+}
+
+// DeleteGcpProject removes the GCP Project with the specified CloudHealth ID.
+func (s *Client) DeleteGcpProject(id int) error {
+	return s.DeleteGcpProjectWithContext(context.Background(), id)
+}
+
+// DeleteGcpProjectWithContext is like DeleteGcpProject but carries ctx through the request and any retries.
+func (s *Client) DeleteGcpProjectWithContext(ctx context.Context, id int) error {
+
+	relativeURL, _ := url.Parse(fmt.Sprintf("gcp_compute_projects/%d", id))
+	url := s.EndpointURL.ResolveReference(relativeURL)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNoContent:
+		return nil
+	default:
+		return newAPIError(resp, responseBody, ErrGcpProjectNotFound)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/nextgenhealthcare/cloudhealth-sdk-go
+
+go 1.13
@@ -0,0 +1,161 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Logger receives the output of a LoggingTransport. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// secretFields are masked in logged JSON bodies.
+var secretFields = map[string]bool{
+	"api_key":                 true,
+	"secret_key":              true,
+	"private_key":             true,
+	"external_id":             true,
+	"assume_role_external_id": true,
+	"generated_external_id":   true,
+}
+
+// LoggingTransport logs every request to CloudHealth and its response: the
+// method, URL, status, latency, headers and pretty-printed bodies. Secrets
+// such as the API key are redacted. See WithLogger.
+type LoggingTransport struct {
+	// Transport sends the requests, http.DefaultTransport if nil.
+	Transport http.RoundTripper
+	Logger    Logger
+}
+
+// NewLoggingTransport returns a LoggingTransport sending requests through transport.
+func NewLoggingTransport(transport http.RoundTripper, logger Logger) *LoggingTransport {
+	return &LoggingTransport{
+		Transport: transport,
+		Logger:    logger,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	reqBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	t.Logger.Printf("CloudHealth API request: %s %s\n%s%s",
+		req.Method, redactURL(req.URL), formatHeaders(req.Header), formatBody(reqBody))
+
+	start := time.Now()
+	resp, err := transport.RoundTrip(req)
+	latency := time.Since(start)
+	if err != nil {
+		t.Logger.Printf("CloudHealth API request failed: %s %s after %s: %s",
+			req.Method, redactURL(req.URL), latency, err)
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+	t.Logger.Printf("CloudHealth API response: %s %s: %s in %s\n%s%s",
+		req.Method, redactURL(req.URL), resp.Status, latency, formatHeaders(resp.Header), formatBody(respBody))
+
+	return resp, nil
+}
+
+// peekRequestBody returns the request body, leaving it unread for the transport.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return ioutil.ReadAll(body)
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func formatHeaders(header http.Header) string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if name == "Authorization" {
+			value = redactedValue
+		}
+		fmt.Fprintf(&b, "%s: %s\n", name, value)
+	}
+	return b.String()
+}
+
+// formatBody pretty-prints a JSON body with its secrets redacted. Other
+// bodies, such as the text CloudHealth answers a new perspective with, are
+// returned as they are.
+func formatBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err != nil {
+		return "\n" + string(body)
+	}
+	pretty, err := json.MarshalIndent(redactSecretFields(parsed), "", "  ")
+	if err != nil {
+		return "\n" + string(body)
+	}
+	return "\n" + string(pretty)
+}
+
+func redactSecretFields(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if secretFields[k] {
+				if s, ok := item.(string); !ok || s != "" {
+					v[k] = redactedValue
+				}
+				continue
+			}
+			v[k] = redactSecretFields(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactSecretFields(item)
+		}
+	}
+	return v
+}
@@ -0,0 +1,66 @@
+package cloudhealth
+
+import (
+	"net/http"
+)
+
+// defaultUserAgent identifies the SDK to CloudHealth unless WithUserAgent says otherwise.
+const defaultUserAgent = "cloudhealth-sdk-go"
+
+// Option configures a Client, see NewClient. The order of options only
+// matters when the same option is given twice, in which case the last wins.
+type Option func(*Client)
+
+// WithTimeout sets the timeout of each request to CloudHealth, in seconds.
+// Zero leaves requests to the http.Client's own timeout.
+func WithTimeout(seconds int) Option {
+	return func(s *Client) {
+		s.Timeout = seconds
+		s.timeoutSet = true
+	}
+}
+
+// WithHTTPClient sends requests through the given http.Client, e.g. one set up
+// with a proxy or instrumentation. Its own timeout applies unless WithTimeout
+// is given as well.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(s *Client) {
+		s.httpClient = httpClient
+	}
+}
+
+// WithBaseTransport sends requests through the given RoundTripper instead of
+// http.DefaultTransport, e.g. to wrap it with logging or metrics.
+func WithBaseTransport(transport http.RoundTripper) Option {
+	return func(s *Client) {
+		s.transport = transport
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with each request.
+func WithUserAgent(userAgent string) Option {
+	return func(s *Client) {
+		s.userAgent = userAgent
+	}
+}
+
+// WithRateLimit limits the client to requestsPerSecond requests on average,
+// allowing bursts of up to burst requests. The limit is shared by every
+// goroutine using the client. A rate of zero or less disables the limit.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(s *Client) {
+		if requestsPerSecond <= 0 {
+			s.limiter = nil
+			return
+		}
+		s.limiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithLogger logs every request and response through logger, with secrets
+// redacted, see LoggingTransport. Use it to debug requests CloudHealth rejects.
+func WithLogger(logger Logger) Option {
+	return func(s *Client) {
+		s.logger = logger
+	}
+}
@@ -0,0 +1,134 @@
+package cloudhealth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestOptionsTimeout(t *testing.T) {
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	cases := []struct {
+		name     string
+		opts     []Option
+		expected int
+	}{
+		{"default", nil, defaultTimeout},
+		{"timeout", []Option{WithTimeout(30)}, 30},
+		{"http client", []Option{WithHTTPClient(httpClient)}, 0},
+		{"timeout then http client", []Option{WithTimeout(30), WithHTTPClient(httpClient)}, 30},
+		{"http client then timeout", []Option{WithHTTPClient(httpClient), WithTimeout(30)}, 30},
+		{"last timeout wins", []Option{WithTimeout(30), WithTimeout(60)}, 60},
+	}
+
+	for _, c := range cases {
+		client, err := NewClient("apiKey", "https://api.foo.bar", c.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if client.Timeout != c.expected {
+			t.Errorf("%s: expected timeout %d, got %d", c.name, c.expected, client.Timeout)
+		}
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var userAgent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"generated_external_id": "x"}`))
+	}))
+	defer ts.Close()
+
+	cases := []struct {
+		opts     []Option
+		expected string
+	}{
+		{nil, defaultUserAgent},
+		{[]Option{WithUserAgent("my-tool/1.0")}, "my-tool/1.0"},
+	}
+
+	for _, c := range cases {
+		client, err := NewClient("apiKey", ts.URL, c.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.GetAwsExternalID(); err != nil {
+			t.Fatal(err)
+		}
+		if userAgent != c.expected {
+			t.Errorf("Expected User-Agent %q, got %q", c.expected, userAgent)
+		}
+	}
+}
+
+func TestWithBaseTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"generated_external_id": "x"}`))
+	}))
+	defer ts.Close()
+
+	httpClient := &http.Client{}
+	for _, reversed := range []bool{false, true} {
+		var requests int
+		transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return http.DefaultTransport.RoundTrip(req)
+		})
+		logger := &bufferLogger{}
+		opts := []Option{WithHTTPClient(httpClient), WithBaseTransport(transport), WithLogger(logger)}
+		if reversed {
+			opts = []Option{opts[2], opts[1], opts[0]}
+		}
+
+		client, err := NewClient("apiKey", ts.URL, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := client.GetAwsExternalID(); err != nil {
+			t.Fatal(err)
+		}
+
+		if requests != 1 {
+			t.Errorf("Expected the request to go through the base transport, got %d requests (reversed %t)", requests, reversed)
+		}
+		// The logger wraps the base transport
+		if !strings.Contains(logger.String(), "CloudHealth API response: GET") {
+			t.Errorf("Expected the request to be logged (reversed %t):\n%s", reversed, logger.String())
+		}
+		// The caller's http.Client is left alone
+		if httpClient.Transport != nil {
+			t.Errorf("Expected the http.Client given to WithHTTPClient to be left unchanged")
+		}
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	cases := []struct {
+		opts    []Option
+		limited bool
+	}{
+		{nil, false},
+		{[]Option{WithRateLimit(5, 2)}, true},
+		{[]Option{WithRateLimit(0, 2)}, false},
+		{[]Option{WithRateLimit(5, 2), WithRateLimit(0, 0)}, false},
+	}
+
+	for i, c := range cases {
+		client, err := NewClient("apiKey", "https://api.foo.bar", c.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if limited := client.limiter != nil; limited != c.limited {
+			t.Errorf("Case %d: expected rate limited %t, got %t", i, c.limited, limited)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package cloudhealth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := newRateLimiter(1, 5)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("Expected a burst of 5 requests not to wait, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterWaitsWhenEmpty(t *testing.T) {
+	l := newRateLimiter(10, 1)
+
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("Expected to wait about 100ms for a token, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterCancelReturnsToken(t *testing.T) {
+	l := newRateLimiter(1, 1)
+
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	// The cancelled caller's token is back, so the next one waits for a
+	// single token rather than two
+	l.mu.Lock()
+	tokens := l.tokens
+	l.mu.Unlock()
+	if tokens < -0.5 {
+		t.Fatalf("Expected the token to be returned, %f tokens left", tokens)
+	}
+}
@@ -0,0 +1,88 @@
+package cloudhealth
+
+import (
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var defaultMaxRetries = 3
+var defaultRetryWaitMin = 1 * time.Second
+var defaultRetryWaitMax = 30 * time.Second
+
+// shouldRetry reports whether a request may be sent again after the given outcome.
+func (s *Client) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if !isIdempotent(req.Method) && !s.RetryNonIdempotent {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns how long to wait before the next attempt, or a negative
+// duration if the server asked for a longer wait than RetryWaitMax.
+func (s *Client) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if retryAfter > s.RetryWaitMax {
+				return -1
+			}
+			return retryAfter
+		}
+	}
+
+	wait := float64(s.RetryWaitMin) * math.Pow(2, float64(attempt))
+	if wait > float64(s.RetryWaitMax) {
+		wait = float64(s.RetryWaitMax)
+	}
+	// Equal jitter: wait between half and all of the computed wait, so that
+	// concurrent callers don't retry in lockstep
+	return time.Duration(wait/2 + rand.Float64()*wait/2)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	default:
+		return false
+	}
+}
+
+// drainBody reads and closes a response body so the connection can be reused.
+func drainBody(body io.ReadCloser) {
+	io.Copy(ioutil.Discard, body)
+	body.Close()
+}
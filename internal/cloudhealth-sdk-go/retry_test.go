@@ -0,0 +1,161 @@
+package cloudhealth
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient returns a client for server that retries without waiting
+// noticeably.
+func newRetryTestClient(t *testing.T, server *httptest.Server) *Client {
+	client, err := NewClient("key", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = 2 * time.Second
+	return client
+}
+
+func TestRetryReplaysBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := newRetryTestClient(t, server)
+
+	req, _ := http.NewRequest("PUT", server.URL, bytes.NewBufferString(`{"name":"a"}`))
+	resp, err := client.do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if len(bodies) != 2 || bodies[0] != `{"name":"a"}` || bodies[1] != bodies[0] {
+		t.Fatalf("Expected the body to be sent twice, got %q", bodies)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var attempts []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts = append(attempts, time.Now())
+		if len(attempts) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	client := newRetryTestClient(t, server)
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := client.do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if len(attempts) != 2 {
+		t.Fatalf("Expected 2 attempts, got %d", len(attempts))
+	}
+	if wait := attempts[1].Sub(attempts[0]); wait < time.Second {
+		t.Fatalf("Expected to wait at least 1s, waited %s", wait)
+	}
+}
+
+func TestRetryAfterLongerThanRetryWaitMax(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	client := newRetryTestClient(t, server)
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := client.do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if attempts != 1 || resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Expected a single attempt returning 429, got %d attempts and %d", attempts, resp.StatusCode)
+	}
+}
+
+func TestRetrySkipsNonIdempotentRequests(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusTooManyRequests} {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(status)
+		}))
+		client := newRetryTestClient(t, server)
+
+		req, _ := http.NewRequest("POST", server.URL, bytes.NewBufferString(`{}`))
+		resp, err := client.do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if attempts != 1 {
+			t.Errorf("Expected a POST answered with %d to be sent once, got %d attempts", status, attempts)
+		}
+
+		// Unless asked to
+		attempts = 0
+		client.RetryNonIdempotent = true
+		req, _ = http.NewRequest("POST", server.URL, bytes.NewBufferString(`{}`))
+		resp, err = client.do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if attempts != client.MaxRetries+1 {
+			t.Errorf("Expected a POST answered with %d to be sent %d times, got %d attempts", status, client.MaxRetries+1, attempts)
+		}
+		server.Close()
+	}
+}
+
+func TestRetryGivesUpAfterMaxRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client := newRetryTestClient(t, server)
+	client.MaxRetries = 2
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := client.do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if attempts != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", attempts)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Expected the last response, got %d", resp.StatusCode)
+	}
+}
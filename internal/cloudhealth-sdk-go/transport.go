@@ -0,0 +1,76 @@
+package cloudhealth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// TransportConfig describes how to reach CloudHealth from networks that go
+// through a proxy or inspect TLS traffic. See NewTransport.
+type TransportConfig struct {
+	// ProxyURL is the proxy requests go through, with credentials in its
+	// user info if it requires them. The HTTPS_PROXY and NO_PROXY
+	// environment variables apply if empty.
+	ProxyURL string
+	// CABundleFile is a PEM file of certificate authorities trusted in
+	// addition to the system ones, e.g. a TLS-inspecting proxy's.
+	CABundleFile string
+	// InsecureSkipVerify disables verification of CloudHealth's certificate.
+	InsecureSkipVerify bool
+	// ClientCertFile and ClientKeyFile are the PEM certificate and key
+	// presented for mutual TLS.
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+// NewTransport returns a transport for use with WithBaseTransport, set up as
+// described by config and otherwise like http.DefaultTransport.
+func NewTransport(config TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %s", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.CABundleFile != "" {
+		pem, err := ioutil.ReadFile(config.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA bundle: %s", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", config.CABundleFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (config.ClientCertFile == "") != (config.ClientKeyFile == "") {
+		return nil, errors.New("a client certificate and key must be given together")
+	}
+	if config.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
@@ -0,0 +1,208 @@
+package cloudhealth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTestKeyPair writes a self-signed certificate and its key to dir and
+// returns the certificate and the paths of both files.
+func writeTestKeyPair(t *testing.T, dir, name string) (*x509.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+"-key.pem")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return cert, certFile, keyFile
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "cloudhealth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func get(transport http.RoundTripper, url string) error {
+	resp, err := (&http.Client{Transport: transport}).Get(url)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	var host, proxyAuthorization string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.URL.Host
+		proxyAuthorization = r.Header.Get("Proxy-Authorization")
+	}))
+	defer proxy.Close()
+
+	proxyURL := strings.Replace(proxy.URL, "http://", "http://user:password@", 1)
+	transport, err := NewTransport(TransportConfig{ProxyURL: proxyURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := get(transport, "http://chapi.example.com/v1/aws_accounts"); err != nil {
+		t.Fatal(err)
+	}
+
+	if host != "chapi.example.com" {
+		t.Errorf("Expected the request for chapi.example.com to go through the proxy, got %q", host)
+	}
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:password"))
+	if proxyAuthorization != expected {
+		t.Errorf("Expected Proxy-Authorization %q, got %q", expected, proxyAuthorization)
+	}
+
+	if _, err := NewTransport(TransportConfig{ProxyURL: "://proxy"}); err == nil || !strings.Contains(err.Error(), "invalid proxy URL") {
+		t.Errorf("Expected an invalid proxy URL error, got %v", err)
+	}
+}
+
+func TestNewTransportTLS(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	caBundleFile := filepath.Join(dir, "ca.pem")
+	if err := ioutil.WriteFile(caBundleFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	notPEMFile := filepath.Join(dir, "not.pem")
+	if err := ioutil.WriteFile(notPEMFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name      string
+		config    TransportConfig
+		configErr string
+		getErr    bool
+	}{
+		{"default", TransportConfig{}, "", true},
+		{"insecure", TransportConfig{InsecureSkipVerify: true}, "", false},
+		{"ca bundle", TransportConfig{CABundleFile: caBundleFile}, "", false},
+		{"missing ca bundle", TransportConfig{CABundleFile: filepath.Join(dir, "missing.pem")}, "could not read CA bundle", false},
+		{"empty ca bundle", TransportConfig{CABundleFile: notPEMFile}, "no certificates found", false},
+	}
+
+	for _, c := range cases {
+		transport, err := NewTransport(c.config)
+		if c.configErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.configErr) {
+				t.Errorf("%s: expected an error containing %q, got %v", c.name, c.configErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if err := get(transport, ts.URL); (err != nil) != c.getErr {
+			t.Errorf("%s: expected a verification error %t, got %v", c.name, c.getErr, err)
+		}
+	}
+}
+
+func TestNewTransportClientCertificate(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	clientCert, certFile, keyFile := writeTestKeyPair(t, dir, "client")
+	_, _, otherKeyFile := writeTestKeyPair(t, dir, "other")
+
+	var peer string
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		peer = r.TLS.PeerCertificates[0].Subject.CommonName
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	ts.StartTLS()
+	defer ts.Close()
+
+	transport, err := NewTransport(TransportConfig{InsecureSkipVerify: true, ClientCertFile: certFile, ClientKeyFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := get(transport, ts.URL); err != nil {
+		t.Fatal(err)
+	}
+	if peer != "client" {
+		t.Errorf("Expected the client certificate to be presented, got %q", peer)
+	}
+
+	// Without it the server refuses the connection
+	transport, err = NewTransport(TransportConfig{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := get(transport, ts.URL); err == nil {
+		t.Errorf("Expected the server to require a client certificate")
+	}
+
+	cases := []struct {
+		name     string
+		config   TransportConfig
+		expected string
+	}{
+		{"certificate only", TransportConfig{ClientCertFile: certFile}, "must be given together"},
+		{"key only", TransportConfig{ClientKeyFile: keyFile}, "must be given together"},
+		{"unreadable key", TransportConfig{ClientCertFile: certFile, ClientKeyFile: filepath.Join(dir, "missing.pem")}, "could not load client certificate"},
+		{"mismatched key", TransportConfig{ClientCertFile: certFile, ClientKeyFile: otherKeyFile}, "could not load client certificate"},
+	}
+	for _, c := range cases {
+		if _, err := NewTransport(c.config); err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("%s: expected an error containing %q, got %v", c.name, c.expected, err)
+		}
+	}
+}
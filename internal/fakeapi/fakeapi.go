@@ -0,0 +1,455 @@
+// Package fakeapi is an in-process fake of the CloudHealth API, so the
+// provider's CRUD logic can be tested without a CloudHealth account.
+//
+// It models the endpoints the provider uses for AWS Accounts and perspectives
+// closely enough to reproduce the API's quirks: 404s with an error body, the
+// "Empty" perspective returned instead of a 404, the plain text
+// "Perspective N created" response and archived perspectives. Like
+// CloudHealth, it keeps the objects of partner customer tenants apart: an
+// object created with a client_api_id is only found with the same one.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nextgenhealthcare/cloudhealth-sdk-go"
+)
+
+// APIKey is the only API key the fake accepts.
+const APIKey = "fake-api-key"
+
+// ExternalID is what the fake generates for AWS IAM Role integration.
+const ExternalID = "fake-external-id"
+
+// Server is a fake CloudHealth API backed by in-memory state.
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	nextID       int
+	accounts     map[int]*account
+	perspectives map[string]*perspective
+}
+
+type account struct {
+	cloudhealth.AwsAccount
+	tenant string
+}
+
+type perspective struct {
+	schema cloudhealth.Schema
+	active bool
+	tenant string
+}
+
+// NewServer starts a fake CloudHealth API. Close it when done.
+func NewServer() *Server {
+	return newServer(httptest.NewServer)
+}
+
+// NewTLSServer starts a fake CloudHealth API served over HTTPS with a
+// self-signed certificate, see Certificate. Close it when done.
+func NewTLSServer() *Server {
+	return newServer(httptest.NewTLSServer)
+}
+
+func newServer(start func(http.Handler) *httptest.Server) *Server {
+	s := &Server{
+		nextID:       1000,
+		accounts:     make(map[int]*account),
+		perspectives: make(map[string]*perspective),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/aws_accounts", s.handleAwsAccounts)
+	mux.HandleFunc("/v1/aws_accounts/", s.handleAwsAccount)
+	mux.HandleFunc("/v1/perspective_schemas", s.handlePerspectives)
+	mux.HandleFunc("/v1/perspective_schemas/", s.handlePerspective)
+	s.Server = start(s.authenticate(mux))
+	return s
+}
+
+// EndpointURL is the URL to configure the provider or SDK with.
+func (s *Server) EndpointURL() string {
+	return s.URL + "/v1/"
+}
+
+// AddPerspective stores a perspective as if it had been created in
+// CloudHealth's own tenant, e.g. an archived one, and returns its ID.
+func (s *Server) AddPerspective(schema cloudhealth.Schema, active bool) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID()
+	s.perspectives[id] = &perspective{schema: addOtherConstant(schema), active: active}
+	return id
+}
+
+// DiscoverDynamicGroup adds a Dynamic Group for val to the categorize group
+// blkID of a perspective, as CloudHealth does when it finds a new value, and
+// returns its ref_id.
+func (s *Server) DiscoverDynamicGroup(id, blkID, val string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := s.perspectives[id]
+	refID := strconv.Itoa(maxRefID(p.schema) + 1)
+	item := cloudhealth.ConstantItem{
+		RefID: refID,
+		BlkID: &blkID,
+		Name:  val,
+		Val:   val,
+	}
+	for i, constant := range p.schema.Constants {
+		if constant.Type == cloudhealth.DynamicGroupType {
+			p.schema.Constants[i].List = append(constant.List, item)
+			return refID
+		}
+	}
+	p.schema.Constants = append(p.schema.Constants, cloudhealth.Constant{
+		Type: cloudhealth.DynamicGroupType,
+		List: []cloudhealth.ConstantItem{item},
+	})
+	return refID
+}
+
+// Tenant returns the client_api_id the AWS Account or perspective with the
+// given ID was created with, empty for the partner's own tenant.
+func (s *Server) Tenant(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.perspectives[id]; ok {
+		return p.tenant
+	}
+	n, _ := strconv.Atoi(id)
+	if a, ok := s.accounts[n]; ok {
+		return a.tenant
+	}
+	return ""
+}
+
+// AwsAccount returns the AWS Account with the given ID as stored, so tests
+// can tell a list that was never sent (nil) from one that was cleared.
+func (s *Server) AwsAccount(id string) (cloudhealth.AwsAccount, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, _ := strconv.Atoi(id)
+	a, ok := s.accounts[n]
+	if !ok {
+		return cloudhealth.AwsAccount{}, false
+	}
+	return a.AwsAccount, true
+}
+
+func (s *Server) newID() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+APIKey {
+			writeError(w, http.StatusUnauthorized, "You need to sign in or sign up before continuing.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleAwsAccounts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case "GET":
+		ids := make([]int, 0, len(s.accounts))
+		for id, a := range s.accounts {
+			if a.tenant == tenant(r) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Ints(ids)
+
+		page, perPage := pagination(r)
+		accounts := make([]cloudhealth.AwsAccount, 0, perPage)
+		for i := (page - 1) * perPage; i >= 0 && i < len(ids) && len(accounts) < perPage; i++ {
+			accounts = append(accounts, s.accounts[ids[i]].AwsAccount)
+		}
+		writeJSON(w, http.StatusOK, cloudhealth.AwsAccounts{Accounts: accounts})
+	case "POST":
+		var create cloudhealth.AwsAccount
+		if !readJSON(w, r, &create) {
+			return
+		}
+		if create.Name == "" {
+			writeErrors(w, http.StatusUnprocessableEntity, "Name can't be blank")
+			return
+		}
+		create.ID, _ = strconv.Atoi(s.newID())
+		s.accounts[create.ID] = &account{AwsAccount: create, tenant: tenant(r)}
+		writeJSON(w, http.StatusCreated, create)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleAwsAccount(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/aws_accounts/")
+	if strings.HasSuffix(path, "/generate_external_id") && r.Method == "GET" {
+		writeJSON(w, http.StatusOK, cloudhealth.AwsExternalID{ExternalID: ExternalID})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, err := strconv.Atoi(path)
+	a, ok := s.accounts[id]
+	if err != nil || !ok || a.tenant != tenant(r) {
+		writeError(w, http.StatusNotFound, "Record not found")
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		writeJSON(w, http.StatusOK, a.AwsAccount)
+	case "PUT":
+		// Like CloudHealth, fields left out of the update keep their value
+		var fields map[string]json.RawMessage
+		if !readJSON(w, r, &fields) {
+			return
+		}
+		current, _ := json.Marshal(a.AwsAccount)
+		var merged map[string]json.RawMessage
+		json.Unmarshal(current, &merged)
+		for k, v := range fields {
+			merged[k] = v
+		}
+		var update cloudhealth.AwsAccount
+		mergedJSON, _ := json.Marshal(merged)
+		json.Unmarshal(mergedJSON, &update)
+		update.ID = id
+		a.AwsAccount = update
+		writeJSON(w, http.StatusOK, update)
+	case "DELETE":
+		delete(s.accounts, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handlePerspectives(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case "GET":
+		perspectives := make(cloudhealth.PerspectiveMap)
+		for id, p := range s.perspectives {
+			if p.tenant == tenant(r) {
+				perspectives[id] = cloudhealth.PerspectiveStatus{Name: p.schema.Name, Active: p.active}
+			}
+		}
+		writeJSON(w, http.StatusOK, perspectives)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handlePerspective(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := strings.TrimPrefix(r.URL.Path, "/v1/perspective_schemas/")
+	if id == "" {
+		if r.Method != "POST" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		s.createPerspective(w, r)
+		return
+	}
+
+	p, ok := s.perspectives[id]
+	ok = ok && p.tenant == tenant(r)
+	switch r.Method {
+	case "GET":
+		if !ok {
+			// CloudHealth answers with an "Empty" perspective rather than a 404
+			writeJSON(w, http.StatusOK, cloudhealth.Perspective{Schema: cloudhealth.Schema{
+				Name:             "Empty",
+				IncludeInReports: "false",
+				Rules:            []cloudhealth.Rule{},
+				Constants:        []cloudhealth.Constant{},
+				Merges:           []cloudhealth.Merge{},
+			}})
+			return
+		}
+		writeJSON(w, http.StatusOK, cloudhealth.Perspective{Schema: p.schema})
+	case "PUT":
+		if !ok {
+			writeError(w, http.StatusNotFound, "Record not found")
+			return
+		}
+		var update cloudhealth.Perspective
+		if !readJSON(w, r, &update) {
+			return
+		}
+		if s.nameTaken(update.Schema.Name, p.tenant, id) {
+			writeErrors(w, http.StatusUnprocessableEntity, "Name has already been taken")
+			return
+		}
+		// Updating an archived perspective makes it active again
+		p.schema = addOtherConstant(update.Schema)
+		p.active = true
+		writeJSON(w, http.StatusOK, cloudhealth.Perspective{Schema: p.schema})
+	case "DELETE":
+		if !ok {
+			writeError(w, http.StatusNotFound, "Record not found")
+			return
+		}
+		if r.URL.Query().Get("hard_delete") == "true" {
+			delete(s.perspectives, id)
+		} else {
+			p.active = false
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) createPerspective(w http.ResponseWriter, r *http.Request) {
+	var create cloudhealth.Perspective
+	if !readJSON(w, r, &create) {
+		return
+	}
+	if create.Schema.Name == "" {
+		writeErrors(w, http.StatusUnprocessableEntity, "Name can't be blank")
+		return
+	}
+	if s.nameTaken(create.Schema.Name, tenant(r), "") {
+		writeErrors(w, http.StatusUnprocessableEntity, "Name has already been taken")
+		return
+	}
+
+	id := s.newID()
+	s.perspectives[id] = &perspective{schema: addOtherConstant(create.Schema), active: true, tenant: tenant(r)}
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusCreated)
+	fmt.Fprintf(w, "Perspective %s created", id)
+}
+
+// nameTaken reports whether another perspective of the tenant, archived or
+// not, has the name.
+func (s *Server) nameTaken(name, tenant, exceptID string) bool {
+	for id, p := range s.perspectives {
+		if id != exceptID && p.tenant == tenant && p.schema.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addOtherConstant adds the "Other" Static Group CloudHealth gives every
+// perspective, which collects the assets no group matched.
+func addOtherConstant(schema cloudhealth.Schema) cloudhealth.Schema {
+	for _, constant := range schema.Constants {
+		for _, item := range constant.List {
+			if constant.Type == cloudhealth.StaticGroupType && item.IsOther == "true" {
+				return schema
+			}
+		}
+	}
+
+	other := cloudhealth.ConstantItem{
+		RefID:   strconv.Itoa(maxRefID(schema) + 1),
+		Name:    "Other",
+		IsOther: "true",
+	}
+	for i, constant := range schema.Constants {
+		if constant.Type == cloudhealth.StaticGroupType {
+			schema.Constants[i].List = append(constant.List, other)
+			return schema
+		}
+	}
+	schema.Constants = append(schema.Constants, cloudhealth.Constant{
+		Type: cloudhealth.StaticGroupType,
+		List: []cloudhealth.ConstantItem{other},
+	})
+	return schema
+}
+
+// maxRefID returns the highest ref_id used by the rules and constants of a
+// schema.
+func maxRefID(schema cloudhealth.Schema) int {
+	max := 0
+	for _, rule := range schema.Rules {
+		if n, err := strconv.Atoi(rule.To); err == nil && n > max {
+			max = n
+		}
+	}
+	for _, constant := range schema.Constants {
+		for _, item := range constant.List {
+			if n, err := strconv.Atoi(item.RefID); err == nil && n > max {
+				max = n
+			}
+		}
+	}
+	return max
+}
+
+// tenant returns the partner customer tenant a request acts on, empty for the
+// partner's own.
+func tenant(r *http.Request) string {
+	return r.URL.Query().Get("client_api_id")
+}
+
+func pagination(r *http.Request) (page, perPage int) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	perPage, err = strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = 30
+	}
+	return page, perPage
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(body, v)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid JSON: %s", err))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeErrors(w http.ResponseWriter, status int, messages ...string) {
+	writeJSON(w, status, map[string][]string{"errors": messages})
+}